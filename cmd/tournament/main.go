@@ -0,0 +1,159 @@
+// Runs matches between two bot implementations without SDL or a window and prints how well each of them
+// did. Useful to tune the bots and to catch regressions in the rules: any error returned by the rules
+// engine stops the tournament with a non zero exit status.
+//
+// The first bot plays North and South in every even match and East and West in every odd match so that
+// both bots get the same share of good seats. Every match is derived from the seed, which makes a
+// tournament fully reproducible.
+//
+// Usage:
+//
+//	go run ./cmd/tournament -variant Spades -a easy -b hard -matches 200 -seed 42
+package main
+
+import (
+	"CardGameGo/src/bots"
+	"CardGameGo/src/game"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// The number of hands after which a match is considered stuck
+const maxHands = 1000
+
+type options struct {
+	variant variants.Variant
+	a, b    string
+	matches int
+	seed    int64
+	deals   int
+}
+
+func main() {
+	variantName := flag.String("variant", variants.All[0].Name(), "the game to play: "+variantNames())
+	a := flag.String("a", "easy", "the first bot: easy or hard")
+	b := flag.String("b", "hard", "the second bot: easy or hard")
+	matches := flag.Int("matches", 100, "the number of matches to play")
+	seed := flag.Int64("seed", 1, "the seed every match is derived from")
+	deals := flag.Int("deals", 20, "the number of deals the hard bot samples for every card")
+	flag.Parse()
+
+	variant, ok := variants.ByName(*variantName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown variant %q, expected one of %s\n", *variantName, variantNames())
+		os.Exit(2)
+	}
+
+	opts := options{variant: variant, a: *a, b: *b, matches: *matches, seed: *seed, deals: *deals}
+	results, err := run(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	report(os.Stdout, opts, results)
+}
+
+func variantNames() string {
+	names := make([]string, len(variants.All))
+	for i, v := range variants.All {
+		names[i] = v.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// Returns a new bot from its name on the command line. The hard bot searches a fixed number of deals
+// instead of using a time budget so that the results only depend on the seed.
+func newBot(name string, seed int64, deals int) (bots.Bot, error) {
+	switch strings.ToLower(name) {
+	case "easy":
+		return bots.NewRuleBot(), nil
+	case "hard":
+		bot := bots.NewMonteCarloBot(seed)
+		bot.Budget = 0
+		bot.Deals = deals
+		return bot, nil
+	default:
+		return nil, errors.New(fmt.Sprintf("bot error: unknown bot %q", name))
+	}
+}
+
+// Plays every match of the tournament and returns the results of both bots
+func run(opts options) ([2]*result, error) {
+	results := [2]*result{{Name: opts.a}, {Name: opts.b}}
+
+	for n := 0; n < opts.matches; n++ {
+		seed := opts.seed + int64(n)*1000
+		a, err := newBot(opts.a, seed, opts.deals)
+		if err != nil {
+			return results, err
+		}
+		b, err := newBot(opts.b, seed+1, opts.deals)
+		if err != nil {
+			return results, err
+		}
+
+		// Alternate the seats of the bots from one match to the next
+		seats := map[int]int{directions.North: 0, directions.South: 0, directions.East: 1, directions.West: 1}
+		if n%2 == 1 {
+			for seat := range seats {
+				seats[seat] = 1 - seats[seat]
+			}
+		}
+		players := [2]bots.Bot{a, b}
+
+		match, err := playMatch(opts.variant, seed, players, seats)
+		if err != nil {
+			return results, errors.New(fmt.Sprintf("match %d (seed %d): %s", n, seed, err))
+		}
+
+		winner, _ := match.Over()
+		for i, team := range match.Score.Teams {
+			owner := seats[team[0]]
+			results[owner].addTeam(match.Score.Totals[i], i == winner)
+		}
+		for _, r := range results {
+			r.endMatch()
+		}
+	}
+	return results, nil
+}
+
+// Plays a whole match, each seat being played by the bot it is assigned to
+func playMatch(variant variants.Variant, seed int64, players [2]bots.Bot, seats map[int]int) (*game.Match, error) {
+	match := game.NewMatch(variant, directions.North, seed)
+	err := match.Start()
+	if err != nil {
+		return nil, err
+	}
+
+	for hands := 1; ; hands++ {
+		for match.Hand.Phase != game.HandOver {
+			seat := match.Hand.Current()
+			err = bots.Act(players[seats[seat]], match.Hand, seat)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		_, err = match.EndHand()
+		if err != nil {
+			return nil, err
+		}
+		if _, over := match.Over(); over {
+			return match, nil
+		}
+		if hands == maxHands {
+			return nil, errors.New(fmt.Sprintf("match error: no winner after %d hands", maxHands))
+		}
+
+		err = match.NextHand()
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// The z score of a 95% confidence interval
+const z95 = 1.96
+
+// The outcome of every match played by a single bot
+type result struct {
+	Name string
+
+	// The number of matches played and won. A match is won when one of the bot's teams wins it
+	Matches int
+	Wins    int
+
+	// The final score of every team of the bot in every match
+	scores   []float64
+	matchWon bool
+}
+
+// Records the final score of one of the bot's teams in the current match
+func (r *result) addTeam(score int, won bool) {
+	r.scores = append(r.scores, float64(score))
+	r.matchWon = r.matchWon || won
+}
+
+func (r *result) endMatch() {
+	r.Matches++
+	if r.matchWon {
+		r.Wins++
+	}
+	r.matchWon = false
+}
+
+// Returns the share of matches won along with the half width of its 95% confidence interval (Wald interval)
+func (r *result) WinRate() (float64, float64) {
+	if r.Matches == 0 {
+		return 0, 0
+	}
+	p := float64(r.Wins) / float64(r.Matches)
+	return p, z95 * math.Sqrt(p*(1-p)/float64(r.Matches))
+}
+
+// Returns the average final score of the bot's teams along with the half width of its 95% confidence interval
+func (r *result) AverageScore() (float64, float64) {
+	n := float64(len(r.scores))
+	if n == 0 {
+		return 0, 0
+	}
+
+	sum := 0.0
+	for _, s := range r.scores {
+		sum += s
+	}
+	mean := sum / n
+	if n == 1 {
+		return mean, 0
+	}
+
+	variance := 0.0
+	for _, s := range r.scores {
+		variance += (s - mean) * (s - mean)
+	}
+	variance /= n - 1
+	return mean, z95 * math.Sqrt(variance/n)
+}
+
+func report(w io.Writer, opts options, results [2]*result) {
+	_, _ = fmt.Fprintf(w, "%s, %d matches, seed %d\n", opts.variant.Name(), opts.matches, opts.seed)
+	_, _ = fmt.Fprintf(w, "%-10s %8s %18s %24s\n", "bot", "wins", "win rate", "average score")
+	for _, r := range results {
+		rate, rateError := r.WinRate()
+		score, scoreError := r.AverageScore()
+		_, _ = fmt.Fprintf(w, "%-10s %8d %9.1f%% ± %4.1f%% %14.1f ± %7.1f\n",
+			r.Name, r.Wins, rate*100, rateError*100, score, scoreError)
+	}
+}
@@ -0,0 +1,46 @@
+// Computer players for the seats that are not taken by a person. A bot is asked for a bid or a card whenever
+// it is its seat's turn and is expected to only ever use the information a person sitting at that seat would
+// have: its own hand, the bids, the cards played so far and the trump.
+//
+// Like the rules engine, this package must never import SDL so that bots can be run by headless tools.
+package bots
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"errors"
+	"fmt"
+)
+
+type Bot interface {
+	// The name shown to the players, for example on the seat's icon
+	Name() string
+
+	// Returns the bid of the seat. Only called during the bidding phase while it is the seat's turn
+	Bid(g *game.Game, seat int) int
+
+	// Returns the card the seat plays. Only called during the playing phase while it is the seat's turn
+	Play(g *game.Game, seat int) cards.Card
+}
+
+// Makes the bot take its turn for the seat, whatever the phase of the game: it bids during the bidding phase,
+// plays a card during the playing phase and collects the trick once it is complete and won by the seat. An
+// error is returned when it is not the seat's turn to do anything.
+func Act(b Bot, g *game.Game, seat int) error {
+	switch {
+	case g.Phase == game.Bidding && g.Current() == seat:
+		return g.Bid(seat, b.Bid(g, seat))
+	case g.Phase == game.Playing && g.Table.TrickComplete() && g.Current() == seat:
+		_, err := g.Collect()
+		return err
+	case g.Phase == game.Playing && g.Current() == seat:
+		return g.Play(seat, b.Play(g, seat))
+	default:
+		return errors.New(fmt.Sprintf("bot error: %s has nothing to do", b.Name()))
+	}
+}
+
+// Reports whether it is the seat's turn to do something. Refer to Act for more info
+func HasTurn(g *game.Game, seat int) bool {
+	return (g.Phase == game.Bidding || g.Phase == game.Playing) && g.Current() == seat
+}
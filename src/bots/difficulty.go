@@ -0,0 +1,38 @@
+package bots
+
+import "CardGameGo/src/cards"
+
+// How strong the computer players are
+type Difficulty int
+
+const (
+	// Rule based bots. Refer to RuleBot for more info
+	Easy Difficulty = iota
+
+	// Monte Carlo search bots. Refer to MonteCarloBot for more info
+	Hard
+)
+
+// Every difficulty, in the order they are offered to the player
+var Difficulties = []Difficulty{Easy, Hard}
+
+func (d Difficulty) String() string {
+	switch d {
+	case Easy:
+		return "Easy"
+	case Hard:
+		return "Hard"
+	default:
+		return ""
+	}
+}
+
+// Returns a new bot of the given difficulty
+func New(d Difficulty) Bot {
+	switch d {
+	case Hard:
+		return NewMonteCarloBot(cards.NewSeed())
+	default:
+		return NewRuleBot()
+	}
+}
@@ -0,0 +1,252 @@
+package bots
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"math/rand"
+	"time"
+)
+
+// The default time a MonteCarloBot may think about a single card. It keeps the bot's turn shorter than a
+// frame of the main loop so that the screen never freezes.
+const DefaultSearchBudget = 30 * time.Millisecond
+
+// The default maximum number of deals a MonteCarloBot samples for a single card
+const DefaultSearchDeals = 200
+
+// The number of times a consistent deal is attempted before the known voids are ignored
+const dealAttempts = 20
+
+// A stronger bot using a determinized Monte Carlo search. The cards it has not seen are dealt at random to
+// the other seats, consistently with what it knows of them (their number of cards and the suits they have
+// shown out of), and every legal card is tried on this deal by playing the rest of the hand with a simpler
+// bot. The card with the best average score over all the deals sampled within the budget is played.
+type MonteCarloBot struct {
+	// The maximum time spent choosing a single card. Zero means no time limit, in which case the number of
+	// deals alone limits the search and the bot plays the same cards for the same seed.
+	Budget time.Duration
+
+	// The maximum number of deals sampled for a single card
+	Deals int
+
+	// The bot playing every seat during the playouts. It also makes the bids
+	Playout Bot
+
+	random *rand.Rand
+}
+
+// Provided constructor. The seed drives the sampling of the deals.
+func NewMonteCarloBot(seed int64) *MonteCarloBot {
+	return &MonteCarloBot{
+		Budget:  DefaultSearchBudget,
+		Deals:   DefaultSearchDeals,
+		Playout: NewRuleBot(),
+		random:  rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (b *MonteCarloBot) Name() string {
+	return "Hard Bot"
+}
+
+func (b *MonteCarloBot) Bid(g *game.Game, seat int) int {
+	return b.Playout.Bid(g, seat)
+}
+
+func (b *MonteCarloBot) Play(g *game.Game, seat int) cards.Card {
+	legal := g.LegalMoves(seat)
+	if len(legal) == 1 {
+		return legal[0]
+	}
+
+	totals := make([]float64, len(legal))
+	start := time.Now()
+	for deal := 0; deal < b.Deals; deal++ {
+		if b.Budget > 0 && deal > 0 && time.Since(start) >= b.Budget {
+			break
+		}
+
+		hands := b.sampleDeal(g, seat)
+		for i, card := range legal {
+			totals[i] += b.playout(g, hands, seat, card)
+		}
+	}
+
+	best := 0
+	for i := range legal {
+		if totals[i] > totals[best] {
+			best = i
+		}
+	}
+	return legal[best]
+}
+
+// Plays the card on a copy of the game where the other seats hold the sampled hands, lets the playout bot
+// finish the hand and returns how good the outcome is for the seat's team
+func (b *MonteCarloBot) playout(g *game.Game, hands map[int][]cards.Card, seat int, card cards.Card) float64 {
+	sim := g.Clone()
+	for s, hand := range hands {
+		sim.Table.Hands[s] = hand
+	}
+
+	err := sim.Play(seat, card)
+	for err == nil && sim.Phase == game.Playing {
+		if sim.Table.TrickComplete() {
+			_, err = sim.Collect()
+		} else {
+			current := sim.Current()
+			err = sim.Play(current, b.Playout.Play(sim, current))
+		}
+	}
+	if err != nil {
+		return 0
+	}
+
+	score := g.Variant.ScoreHand(sim.Result(), scoring.NewMatch(g.Variant.Teams()))
+	return evaluate(g.Variant, score, variants.TeamOf(g.Variant, seat))
+}
+
+// Returns the points of the team minus the average points of the other teams, negated for the variants
+// where the lowest score wins
+func evaluate(v variants.Variant, score scoring.HandScore, team int) float64 {
+	others := 0.0
+	for i, t := range score.Teams {
+		if i != team {
+			others += float64(t.Points)
+		}
+	}
+	value := float64(score.Teams[team].Points) - others/float64(len(score.Teams)-1)
+
+	if _, hearts := v.(variants.Hearts); hearts {
+		return -value
+	}
+	return value
+}
+
+// Deals the cards the seat has not seen to the other seats at random. Every seat receives as many cards as
+// it currently holds and, whenever possible, no card of a suit it has already shown out of.
+func (b *MonteCarloBot) sampleDeal(g *game.Game, seat int) map[int][]cards.Card {
+	unknown := unseen(g, seat)
+	void := voids(g.Table)
+
+	for attempt := 0; attempt < dealAttempts; attempt++ {
+		if hands, ok := b.deal(g.Table, seat, unknown, void); ok {
+			return hands
+		}
+	}
+	return b.dealIgnoringVoids(g.Table, seat, unknown)
+}
+
+// Tries to deal the unknown cards while respecting the voids. Reports false if the deal ran into a dead end.
+func (b *MonteCarloBot) deal(t *rules.Table, seat int, unknown []cards.Card,
+	void map[int]map[cards.Suit]bool) (map[int][]cards.Card, bool) {
+
+	b.random.Shuffle(len(unknown), func(i, j int) {
+		unknown[i], unknown[j] = unknown[j], unknown[i]
+	})
+
+	hands := make(map[int][]cards.Card)
+	room := make(map[int]int)
+	for _, s := range directions.Order {
+		if s != seat {
+			hands[s] = make([]cards.Card, 0, len(t.Hands[s]))
+			room[s] = len(t.Hands[s])
+		}
+	}
+
+	for _, card := range unknown {
+		// Pick a seat that may hold the card, weighted by the room left in its hand
+		total := 0
+		for s, r := range room {
+			if !void[s][card.Suit] {
+				total += r
+			}
+		}
+		if total == 0 {
+			return nil, false
+		}
+
+		pick := b.random.Intn(total)
+		for _, s := range directions.Order {
+			if s == seat || void[s][card.Suit] {
+				continue
+			}
+			if pick < room[s] {
+				hands[s] = append(hands[s], card)
+				room[s]--
+				break
+			}
+			pick -= room[s]
+		}
+	}
+	return hands, true
+}
+
+// Deals the unknown cards ignoring the voids. Only used when no consistent deal could be found.
+func (b *MonteCarloBot) dealIgnoringVoids(t *rules.Table, seat int, unknown []cards.Card) map[int][]cards.Card {
+	b.random.Shuffle(len(unknown), func(i, j int) {
+		unknown[i], unknown[j] = unknown[j], unknown[i]
+	})
+
+	hands := make(map[int][]cards.Card)
+	next := 0
+	for _, s := range directions.Order {
+		if s != seat {
+			size := len(t.Hands[s])
+			hands[s] = append([]cards.Card(nil), unknown[next:next+size]...)
+			next += size
+		}
+	}
+	return hands
+}
+
+// Returns the cards of the deck that are neither held by the seat nor played so far
+func unseen(g *game.Game, seat int) []cards.Card {
+	seen := make(map[cards.Card]bool)
+	for _, card := range g.Hand(seat) {
+		seen[card] = true
+	}
+	for _, trick := range g.Table.Tricks {
+		for _, play := range trick.Plays {
+			seen[play.Card] = true
+		}
+	}
+	for _, play := range g.Table.Trick.Plays {
+		seen[play.Card] = true
+	}
+
+	deck := g.Variant.NewDeck()
+	result := make([]cards.Card, 0, deck.Len())
+	for _, card := range deck.Cards {
+		if !seen[card] {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+// Returns the suits every seat is known to be out of, because it did not follow them when they were led
+func voids(t *rules.Table) map[int]map[cards.Suit]bool {
+	result := make(map[int]map[cards.Suit]bool)
+	for _, seat := range directions.Order {
+		result[seat] = make(map[cards.Suit]bool)
+	}
+
+	record := func(trick rules.Trick) {
+		lead := trick.LeadSuit()
+		for _, play := range trick.Plays {
+			if play.Card.Suit != lead {
+				result[play.Seat][lead] = true
+			}
+		}
+	}
+	for _, trick := range t.Tricks {
+		record(trick)
+	}
+	record(t.Trick)
+	return result
+}
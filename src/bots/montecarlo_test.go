@@ -0,0 +1,93 @@
+package bots
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"testing"
+	"time"
+)
+
+func TestSampledDealsRespectTheVoids(t *testing.T) {
+	hands := [4]string{
+		"h2 c2 c3 c4 c5 c6 c7 c8 c9 cX cJ cQ cK",
+		"d2 d3 d4 d5 d6 d7 d8 d9 dX dJ dQ dK d1",
+		"h3 h4 h5 h6 h7 h8 h9 c1 s2 s3 s4 s5 s6",
+		"hX hJ hQ hK h1 s7 s8 s9 sX sJ sQ sK s1",
+	}
+	// East shows out of hearts on the first trick, won by West who leads the next one
+	g := newTestGame(t, hands, cards.Spades, "h2 d2 h3 hX")
+	if _, err := g.Collect(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := make(map[cards.Card]bool)
+	for _, card := range unseen(g, directions.West) {
+		expected[card] = true
+	}
+
+	b := NewMonteCarloBot(1)
+	for i := 0; i < 200; i++ {
+		sampled := b.sampleDeal(g, directions.West)
+		dealt := 0
+		for _, seat := range directions.Order {
+			hand := sampled[seat]
+			if seat == directions.West {
+				if hand != nil {
+					t.Fatal("cards were dealt to the searching seat")
+				}
+				continue
+			}
+			if len(hand) != len(g.Hand(seat)) {
+				t.Fatalf("%s was dealt %d cards, expected %d", directions.String(seat), len(hand),
+					len(g.Hand(seat)))
+			}
+			for _, card := range hand {
+				if !expected[card] {
+					t.Fatalf("%s was dealt %s, which West has already seen", directions.String(seat), card)
+				}
+				if seat == directions.East && card.Suit == cards.Hearts {
+					t.Fatalf("East was dealt %s after showing out of hearts", card)
+				}
+			}
+			dealt += len(hand)
+		}
+		if dealt != len(expected) {
+			t.Fatalf("%d cards were dealt, expected the %d unseen ones", dealt, len(expected))
+		}
+	}
+}
+
+func TestTheBudgetLimitsTheSearch(t *testing.T) {
+	g := game.New(variants.Spades{}, directions.West, 42)
+	if err := g.Deal(); err != nil {
+		t.Fatal(err)
+	}
+	for g.Phase == game.Bidding {
+		seat := g.Current()
+		if err := g.Bid(seat, NewRuleBot().Bid(g, seat)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Zero means no time limit, the number of deals alone bounds the search then. The slack is generous,
+	// as the race detector slows a single playout down a lot
+	const slack = time.Second
+	for _, budget := range []time.Duration{0, time.Nanosecond, time.Millisecond, DefaultSearchBudget} {
+		b := NewMonteCarloBot(1)
+		b.Budget = budget
+		seat := g.Current()
+
+		start := time.Now()
+		card := b.Play(g, seat)
+		elapsed := time.Since(start)
+
+		if !cards.Contains(g.LegalMoves(seat), card) {
+			t.Errorf("with a budget of %s: played %s, which is not legal", budget, card)
+		}
+		if budget > 0 && elapsed > budget+slack {
+			t.Errorf("with a budget of %s: searched for %s", budget, elapsed)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package bots
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+)
+
+// A simple rule based bot. It follows the basic strategy of most trick taking games:
+//   - when its partner is already winning the trick, it dumps its lowest card
+//   - when it can win the trick, it plays high to win (but only as high as needed when it plays last)
+//   - when it cannot win the trick, it dumps its lowest card, keeping its trumps for later
+//
+// In Hearts, where tricks are to be avoided, the strategy is reversed: it plays the highest card that does
+// not win the trick and gets rid of its dangerous cards when it cannot follow suit.
+type RuleBot struct{}
+
+// Provided constructor
+func NewRuleBot() *RuleBot {
+	return &RuleBot{}
+}
+
+func (b *RuleBot) Name() string {
+	return "Bot"
+}
+
+// Bids the number of tricks the hand is expected to win: aces, guarded kings and long trumps. The bid is
+// then adjusted to the closest legal bid.
+func (b *RuleBot) Bid(g *game.Game, seat int) int {
+	hand := g.Hand(seat)
+	expected := 0
+	for _, suit := range cards.Suits {
+		suited := cards.OfSuit(hand, suit)
+		for _, card := range suited {
+			switch {
+			case card.Rank == cards.Ace:
+				expected++
+			case card.Rank == cards.King && len(suited) >= 2:
+				expected++
+			}
+		}
+		if suit == g.Trump && len(suited) > 3 {
+			expected += len(suited) - 3
+		}
+	}
+
+	legal := g.LegalBids(seat)
+	best := legal[0]
+	for _, bid := range legal {
+		if abs(bid-expected) < abs(best-expected) {
+			best = bid
+		}
+	}
+	return best
+}
+
+func (b *RuleBot) Play(g *game.Game, seat int) cards.Card {
+	legal := g.LegalMoves(seat)
+	if len(legal) == 1 {
+		return legal[0]
+	}
+
+	if _, hearts := g.Variant.(variants.Hearts); hearts {
+		return playToLose(g.Table, legal)
+	}
+	return playToWin(g, seat, legal)
+}
+
+func playToWin(g *game.Game, seat int, legal []cards.Card) cards.Card {
+	table := g.Table
+	trick := &table.Trick
+
+	// Lead the highest card of the hand if it is an ace, otherwise a low card to keep the strong ones
+	if len(trick.Plays) == 0 {
+		nonTrumps := variants.WithoutSuit(legal, table.Trump)
+		if len(nonTrumps) == 0 {
+			nonTrumps = legal
+		}
+		if highest := cards.Highest(nonTrumps); highest.Rank == cards.Ace {
+			return highest
+		}
+		return cards.Lowest(nonTrumps)
+	}
+
+	winning := trick.Winner(table.Trump)
+	if variants.TeamOf(g.Variant, winning.Seat) == variants.TeamOf(g.Variant, seat) {
+		return dumpLowest(legal, table.Trump)
+	}
+
+	winners := make([]cards.Card, 0, len(legal))
+	for _, card := range legal {
+		if rules.Beats(card, winning.Card, table.Trump) {
+			winners = append(winners, card)
+		}
+	}
+	if len(winners) == 0 {
+		return dumpLowest(legal, table.Trump)
+	}
+
+	// The last seat only needs to win, the others play high so that the seats after them cannot overtake
+	lastToPlay := len(trick.Plays) == len(directions.Order)-1
+	if lastToPlay {
+		return lowestWinner(winners, table.Trump)
+	}
+	return highestWinner(winners, table.Trump)
+}
+
+func playToLose(table *rules.Table, legal []cards.Card) cards.Card {
+	trick := &table.Trick
+	if len(trick.Plays) == 0 {
+		return cards.Lowest(legal)
+	}
+
+	winning := trick.Winner(table.Trump)
+	losers := make([]cards.Card, 0, len(legal))
+	for _, card := range legal {
+		if !rules.Beats(card, winning.Card, table.Trump) {
+			losers = append(losers, card)
+		}
+	}
+
+	if len(losers) == 0 {
+		return cards.Highest(legal)
+	}
+
+	// Discarding on another suit is the chance to get rid of the queen of spades and of high hearts
+	if losers[0].Suit != trick.LeadSuit() {
+		best := losers[0]
+		for _, card := range losers {
+			if variants.HeartsPoints(card) > variants.HeartsPoints(best) ||
+				(variants.HeartsPoints(card) == variants.HeartsPoints(best) && cards.CompareRank(card, best) > 0) {
+				best = card
+			}
+		}
+		return best
+	}
+	return cards.Highest(losers)
+}
+
+// Returns the lowest card, preferring cards that are not trumps
+func dumpLowest(legal []cards.Card, trump cards.Suit) cards.Card {
+	if nonTrumps := variants.WithoutSuit(legal, trump); len(nonTrumps) > 0 {
+		return cards.Lowest(nonTrumps)
+	}
+	return cards.Lowest(legal)
+}
+
+// Returns the cheapest winning card: a card of the suit led if possible, the lowest trump otherwise
+func lowestWinner(winners []cards.Card, trump cards.Suit) cards.Card {
+	if nonTrumps := variants.WithoutSuit(winners, trump); len(nonTrumps) > 0 {
+		return cards.Lowest(nonTrumps)
+	}
+	return cards.Lowest(winners)
+}
+
+// Returns the strongest winning card, without wasting a trump when a card of the suit led wins as well
+func highestWinner(winners []cards.Card, trump cards.Suit) cards.Card {
+	if nonTrumps := variants.WithoutSuit(winners, trump); len(nonTrumps) > 0 {
+		return cards.Highest(nonTrumps)
+	}
+	return cards.Lowest(winners)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
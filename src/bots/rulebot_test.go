@@ -0,0 +1,152 @@
+package bots
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"strings"
+	"testing"
+)
+
+// Parses cards written like "h2 sQ c1", refer to cards.Parse
+func parse(t *testing.T, names string) []cards.Card {
+	hand, err := cards.ParseAll(strings.Fields(names)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hand
+}
+
+// Starts a hand of Court Piece dealt by West with the given hands, in directions.Order, and trump, and plays
+// the cards in turn, collecting the complete tricks. North leads the first trick
+func newTestGame(t *testing.T, hands [4]string, trump cards.Suit, played string) *game.Game {
+	g := game.New(variants.CourtPiece{}, directions.West, 1)
+	g.Dealt = make(map[int][]cards.Card)
+	for i, seat := range directions.Order {
+		g.Dealt[seat] = parse(t, hands[i])
+	}
+	g.Trump = trump
+	g.Table = variants.NewTable(g.Variant, g.Dealt, g.Dealer)
+	g.Table.Trump = trump
+	g.Phase = game.Playing
+
+	for _, card := range parse(t, played) {
+		if g.Table.TrickComplete() {
+			_, err := g.Collect()
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		err := g.Play(g.Current(), card)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+func TestRuleBotPlays(t *testing.T) {
+	tests := []struct {
+		name   string
+		hands  [4]string
+		trump  cards.Suit
+		played string
+		card   string
+	}{
+		{
+			name:   "follows suit and plays high to win",
+			hands:  [4]string{"h5", "h2 hK d1 s1", "", ""},
+			trump:  cards.Clubs,
+			played: "h5",
+			card:   "hK",
+		},
+		{
+			name:   "dumps its lowest card of the suit when it cannot win",
+			hands:  [4]string{"h1", "h9 h3 d2", "", ""},
+			trump:  cards.Clubs,
+			played: "h1",
+			card:   "h3",
+		},
+		{
+			name:   "dumps its lowest card when void and without trumps",
+			hands:  [4]string{"hK", "d9 s5 d2", "", ""},
+			trump:  cards.Clubs,
+			played: "hK",
+			card:   "d2",
+		},
+		{
+			name:   "trumps when void",
+			hands:  [4]string{"h5", "d1 c3", "", ""},
+			trump:  cards.Clubs,
+			played: "h5",
+			card:   "c3",
+		},
+		{
+			name:   "dumps low under its partner's winning card",
+			hands:  [4]string{"h1", "h2", "hK h3 d4", ""},
+			trump:  cards.Clubs,
+			played: "h1 h2",
+			card:   "h3",
+		},
+		{
+			name:   "only wins as high as needed when playing last",
+			hands:  [4]string{"h5", "h9", "h2", "hK hX hQ"},
+			trump:  cards.Clubs,
+			played: "h5 h9 h2",
+			card:   "hX",
+		},
+	}
+	for _, test := range tests {
+		g := newTestGame(t, test.hands, test.trump, test.played)
+		seat := g.Current()
+		card := NewRuleBot().Play(g, seat)
+		if !cards.Contains(g.LegalMoves(seat), card) {
+			t.Errorf("%s: played %s, which is not legal", test.name, card)
+			continue
+		}
+		if card != cards.MustParse(test.card) {
+			t.Errorf("%s: played %s, expected %s", test.name, card, test.card)
+		}
+	}
+}
+
+func TestRuleBotOnlyMakesLegalMoves(t *testing.T) {
+	bot := NewRuleBot()
+	for _, variant := range variants.All {
+		for seed := int64(1); seed <= 10; seed++ {
+			g := game.New(variant, directions.East, seed)
+			err := g.Deal()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for g.Phase != game.HandOver {
+				seat := g.Current()
+				switch {
+				case g.Phase == game.Bidding:
+					bid := bot.Bid(g, seat)
+					legal := false
+					for _, b := range g.LegalBids(seat) {
+						legal = legal || b == bid
+					}
+					if !legal {
+						t.Fatalf("%s, seed %d: bid %d is not legal", variant.Name(), seed, bid)
+					}
+					err = g.Bid(seat, bid)
+				case g.Table.TrickComplete():
+					_, err = g.Collect()
+				default:
+					card := bot.Play(g, seat)
+					if !cards.Contains(g.LegalMoves(seat), card) {
+						t.Fatalf("%s, seed %d: %s is not legal", variant.Name(), seed, card)
+					}
+					err = g.Play(seat, card)
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+}
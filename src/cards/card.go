@@ -0,0 +1,344 @@
+// The card model used throughout the game logic. Cards used to be passed around as bare strings matching the
+// asset names ("c1", "hK", ...) which meant every piece of game logic had to slice strings to find a suit or a
+// rank. This package provides typed Suit, Rank and Card values along with the conversions to and from the asset
+// naming scheme so that the rest of the application never has to deal with the raw names.
+//
+// The asset naming scheme is a single suit letter (c, d, h, s) followed by a single rank character where aces are
+// "1", tens are "X" and the picture cards are "J", "Q" and "K". All card faces are stored under the
+// assets/images/cards/fronts directory.
+//
+// This package must never import SDL so that it can be used by headless tools and tests.
+package cards
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// The directory (relative to assets/images) that holds the card faces
+const frontsDir = "cards/fronts/"
+
+type Suit int
+
+// The zero value NoSuit is used wherever a suit is optional, for example when a game has no trump suit
+const (
+	NoSuit Suit = iota
+	Clubs
+	Diamonds
+	Hearts
+	Spades
+)
+
+// All the real suits in the order used when sorting a hand
+var Suits = [...]Suit{Clubs, Diamonds, Hearts, Spades}
+
+type Rank int
+
+// Ranks are ordered with aces high as this is how almost every trick taking game treats them. The zero value
+// NoRank denotes the absence of a card.
+const (
+	NoRank Rank = iota
+	Two    Rank = iota + 1
+	Three
+	Four
+	Five
+	Six
+	Seven
+	Eight
+	Nine
+	Ten
+	Jack
+	Queen
+	King
+	Ace
+)
+
+// All the ranks in ascending order
+var Ranks = [...]Rank{Two, Three, Four, Five, Six, Seven, Eight, Nine, Ten, Jack, Queen, King, Ace}
+
+// A single playing card. The zero value is not a valid card and is used to represent "no card", for example an
+// empty slot on the table.
+type Card struct {
+	Suit Suit
+	Rank Rank
+}
+
+var suitLetters = map[Suit]byte{
+	Clubs:    'c',
+	Diamonds: 'd',
+	Hearts:   'h',
+	Spades:   's',
+}
+
+var rankLetters = map[Rank]byte{
+	Two:   '2',
+	Three: '3',
+	Four:  '4',
+	Five:  '5',
+	Six:   '6',
+	Seven: '7',
+	Eight: '8',
+	Nine:  '9',
+	Ten:   'X',
+	Jack:  'J',
+	Queen: 'Q',
+	King:  'K',
+	Ace:   '1',
+}
+
+var suitNames = map[Suit]string{
+	NoSuit:   "None",
+	Clubs:    "Clubs",
+	Diamonds: "Diamonds",
+	Hearts:   "Hearts",
+	Spades:   "Spades",
+}
+
+var rankNames = map[Rank]string{
+	Two:   "Two",
+	Three: "Three",
+	Four:  "Four",
+	Five:  "Five",
+	Six:   "Six",
+	Seven: "Seven",
+	Eight: "Eight",
+	Nine:  "Nine",
+	Ten:   "Ten",
+	Jack:  "Jack",
+	Queen: "Queen",
+	King:  "King",
+	Ace:   "Ace",
+}
+
+// Provided constructor
+func New(suit Suit, rank Rank) Card {
+	return Card{Suit: suit, Rank: rank}
+}
+
+// Parses a card from its asset name, for example "hK" for the king of hearts or "cX" for the ten of clubs
+func Parse(name string) (Card, error) {
+	if len(name) != 2 {
+		return Card{}, errors.New(fmt.Sprintf("card parse error: %q is not a valid card name", name))
+	}
+
+	var card Card
+	for suit, letter := range suitLetters {
+		if letter == name[0] {
+			card.Suit = suit
+		}
+	}
+	for rank, letter := range rankLetters {
+		if letter == name[1] {
+			card.Rank = rank
+		}
+	}
+
+	if !card.Valid() {
+		return Card{}, errors.New(fmt.Sprintf("card parse error: %q is not a valid card name", name))
+	}
+	return card, nil
+}
+
+// Same as Parse but panics on an invalid name. Only meant to be used for hard coded cards.
+func MustParse(name string) Card {
+	card, err := Parse(name)
+	if err != nil {
+		panic(err)
+	}
+	return card
+}
+
+// Parses a list of asset names into cards, failing on the first invalid name
+func ParseAll(names ...string) ([]Card, error) {
+	hand := make([]Card, len(names))
+	for i, name := range names {
+		card, err := Parse(name)
+		if err != nil {
+			return nil, err
+		}
+		hand[i] = card
+	}
+	return hand, nil
+}
+
+// Reports whether the card is a real card as opposed to the zero value or an out of range value
+func (c Card) Valid() bool {
+	return c.Suit.Valid() && c.Rank.Valid()
+}
+
+// Reports whether the card is the zero value, i.e. "no card"
+func (c Card) IsZero() bool {
+	return c == Card{}
+}
+
+// Formats the card using the asset naming scheme. Invalid cards are formatted as an empty string so that the
+// output can be used directly in places that previously used "" for "no card".
+func (c Card) String() string {
+	if !c.Valid() {
+		return ""
+	}
+	return string([]byte{suitLetters[c.Suit], rankLetters[c.Rank]})
+}
+
+// Encodes the card using the asset naming scheme so that cards are written as "sQ" in JSON, including as map
+// keys. The zero card is encoded as an empty string.
+func (c Card) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// Decodes a card written by MarshalText
+func (c *Card) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = Card{}
+		return nil
+	}
+	card, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*c = card
+	return nil
+}
+
+// A human readable name such as "Queen of Spades"
+func (c Card) Name() string {
+	if !c.Valid() {
+		return ""
+	}
+	return rankNames[c.Rank] + " of " + suitNames[c.Suit]
+}
+
+// The key under which the card face is stored in imgmanager.ImageManager.Images
+func (c Card) TextureKey() string {
+	return frontsDir + c.String()
+}
+
+// The path of the card face relative to the assets/images directory, as expected by imgmanager.LOADED_IMAGES
+func (c Card) ImagePath() string {
+	return c.TextureKey() + ".png"
+}
+
+func (s Suit) Valid() bool {
+	return s >= Clubs && s <= Spades
+}
+
+func (s Suit) String() string {
+	return suitNames[s]
+}
+
+func (r Rank) Valid() bool {
+	return r >= Two && r <= Ace
+}
+
+func (r Rank) String() string {
+	return rankNames[r]
+}
+
+// Compares two cards by rank only, returning -1, 0 or 1. Suits are ignored as whether a card of a different
+// suit can win depends on the rules of the game being played.
+func CompareRank(a, b Card) int {
+	switch {
+	case a.Rank < b.Rank:
+		return -1
+	case a.Rank > b.Rank:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Compares two cards by suit first and rank second, returning -1, 0 or 1. This is the ordering used to
+// present a hand to the player.
+func Compare(a, b Card) int {
+	switch {
+	case a.Suit < b.Suit:
+		return -1
+	case a.Suit > b.Suit:
+		return 1
+	default:
+		return CompareRank(a, b)
+	}
+}
+
+// Reports whether a sorts before b using Compare
+func Less(a, b Card) bool {
+	return Compare(a, b) < 0
+}
+
+// Sorts a hand in place by suit and then by rank
+func Sort(hand []Card) {
+	sort.Slice(hand, func(i, j int) bool {
+		return Less(hand[i], hand[j])
+	})
+}
+
+// Returns the highest ranked card of the hand, or the zero card if the hand is empty
+func Highest(hand []Card) Card {
+	var best Card
+	for _, card := range hand {
+		if best.IsZero() || CompareRank(card, best) > 0 {
+			best = card
+		}
+	}
+	return best
+}
+
+// Returns the lowest ranked card of the hand, or the zero card if the hand is empty
+func Lowest(hand []Card) Card {
+	var best Card
+	for _, card := range hand {
+		if best.IsZero() || CompareRank(card, best) < 0 {
+			best = card
+		}
+	}
+	return best
+}
+
+// Returns the cards of the hand that belong to the given suit
+func OfSuit(hand []Card, suit Suit) []Card {
+	result := make([]Card, 0, len(hand))
+	for _, card := range hand {
+		if card.Suit == suit {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+// Reports whether the hand contains the given card
+func Contains(hand []Card, card Card) bool {
+	return IndexOf(hand, card) >= 0
+}
+
+// Returns the position of the card in the hand or -1 if the hand does not contain it
+func IndexOf(hand []Card, card Card) int {
+	for i, c := range hand {
+		if c == card {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns a copy of the hand without the given card. The original hand is left untouched.
+func Remove(hand []Card, card Card) []Card {
+	result := make([]Card, 0, len(hand))
+	for _, c := range hand {
+		if c != card {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// Returns every card of a standard 52 card deck in suit and rank order
+func All() []Card {
+	all := make([]Card, 0, len(Suits)*len(Ranks))
+	for _, suit := range Suits {
+		for _, rank := range Ranks {
+			all = append(all, New(suit, rank))
+		}
+	}
+	return all
+}
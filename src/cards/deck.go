@@ -0,0 +1,86 @@
+package cards
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// A deck of cards. The top of the deck is the end of the Cards slice, so dealing pops cards off the end.
+type Deck struct {
+	Cards []Card
+}
+
+// Returns a new standard 52 card deck in suit and rank order. Call Shuffle before dealing.
+func NewDeck() *Deck {
+	return &Deck{Cards: All()}
+}
+
+// Returns a new deck made of the given cards. Useful for games that play with a stripped deck.
+func NewDeckOf(deckCards []Card) *Deck {
+	c := make([]Card, len(deckCards))
+	copy(c, deckCards)
+	return &Deck{Cards: c}
+}
+
+// Returns a seed derived from the current time. Use this when a game does not need to be reproducible.
+func NewSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+// Shuffles the deck using the provided seed. The same seed always produces the same order for a deck with the
+// same starting order, which allows a specific deal to be reproduced.
+func (d *Deck) Shuffle(seed int64) {
+	d.ShuffleWith(rand.New(rand.NewSource(seed)))
+}
+
+// Shuffles the deck using the provided random number generator
+func (d *Deck) ShuffleWith(rng *rand.Rand) {
+	rng.Shuffle(len(d.Cards), func(i, j int) {
+		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
+	})
+}
+
+// The number of cards left in the deck
+func (d *Deck) Len() int {
+	return len(d.Cards)
+}
+
+// Removes and returns the top card of the deck
+func (d *Deck) Draw() (Card, error) {
+	if len(d.Cards) == 0 {
+		return Card{}, errors.New("deck error: cannot draw from an empty deck")
+	}
+	card := d.Cards[len(d.Cards)-1]
+	d.Cards = d.Cards[:len(d.Cards)-1]
+	return card, nil
+}
+
+// Deals the given number of hands of the given size, one card at a time to every hand in turn as would be
+// done at a real table. Every returned hand is sorted. The dealt cards are removed from the deck.
+func (d *Deck) Deal(hands, size int) ([][]Card, error) {
+	if hands <= 0 || size < 0 {
+		return nil, errors.New(fmt.Sprintf("deck error: cannot deal %d hands of %d cards", hands, size))
+	}
+	if hands*size > len(d.Cards) {
+		return nil, errors.New(fmt.Sprintf("deck error: cannot deal %d hands of %d cards from %d cards",
+			hands, size, len(d.Cards)))
+	}
+
+	dealt := make([][]Card, hands)
+	for i := range dealt {
+		dealt[i] = make([]Card, 0, size)
+	}
+	for round := 0; round < size; round++ {
+		for i := range dealt {
+			card, _ := d.Draw()
+			dealt[i] = append(dealt[i], card)
+		}
+	}
+
+	for _, hand := range dealt {
+		Sort(hand)
+	}
+	return dealt, nil
+}
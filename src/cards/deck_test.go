@@ -0,0 +1,57 @@
+package cards
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Shuffles a new deck with the seed and deals it to four seats
+func dealFrom(t *testing.T, seed int64) [][]Card {
+	deck := NewDeck()
+	deck.Shuffle(seed)
+	hands, err := deck.Deal(4, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deck.Len() != 0 {
+		t.Fatalf("%d cards left in the deck", deck.Len())
+	}
+	return hands
+}
+
+func TestDealsEveryCardOnce(t *testing.T) {
+	seen := make(map[Card]bool)
+	for _, hand := range dealFrom(t, 42) {
+		if len(hand) != 13 {
+			t.Fatalf("a hand of %d cards was dealt", len(hand))
+		}
+		for _, card := range hand {
+			if !card.Valid() || seen[card] {
+				t.Fatalf("%s was dealt twice or is not a card", card)
+			}
+			seen[card] = true
+		}
+	}
+	if len(seen) != 52 {
+		t.Fatalf("%d distinct cards were dealt", len(seen))
+	}
+}
+
+func TestTheSameSeedDealsTheSameHands(t *testing.T) {
+	if !reflect.DeepEqual(dealFrom(t, 42), dealFrom(t, 42)) {
+		t.Fatal("the same seed dealt different hands")
+	}
+	if reflect.DeepEqual(dealFrom(t, 42), dealFrom(t, 43)) {
+		t.Fatal("different seeds dealt the same hands")
+	}
+}
+
+func TestDealingMoreCardsThanTheDeckHolds(t *testing.T) {
+	deck := NewDeck()
+	if _, err := deck.Deal(4, 14); err == nil {
+		t.Fatal("56 cards were dealt from a deck of 52")
+	}
+	if deck.Len() != 52 {
+		t.Fatal("the failed deal took cards from the deck")
+	}
+}
@@ -1,6 +1,9 @@
 package imagebutton
 
-import "github.com/veandco/go-sdl2/sdl"
+import (
+	"CardGameGo/src/utils"
+	"github.com/veandco/go-sdl2/sdl"
+)
 
 // An implementation of the button interface. It provides an image that can run a callback upon being
 // clicked
@@ -14,6 +17,16 @@ type ImageButton struct {
 	imageTexture *sdl.Texture
 
 	CallBack func(...interface{}) error
+
+	// The callbacks run when the button is held down with a finger and when it is swiped in a direction, one
+	// of the events.Swipe* constants. Both are optional, refer to events.GestureEvent
+	LongPressCallBack func(...interface{}) error
+	SwipeCallBacks    map[int]func(...interface{}) error
+
+	// Check rectbutton.RectangularButton for more details. The image is outlined while hovered and drawn
+	// darker while pressed
+	Hovered bool
+	Pressed bool
 }
 
 // Provided Constructor
@@ -41,7 +54,17 @@ func (btn *ImageButton) Draw(x, y int32, renderer *sdl.Renderer) error {
 		H: btn.Height,
 	}
 
-	return renderer.Copy(btn.imageTexture, nil, &rect)
+	if btn.Pressed {
+		_ = btn.imageTexture.SetColorMod(190, 190, 190)
+		defer btn.imageTexture.SetColorMod(255, 255, 255)
+	}
+	err := renderer.Copy(btn.imageTexture, nil, &rect)
+	if err != nil || !btn.Hovered {
+		return err
+	}
+
+	_ = renderer.SetDrawColor(utils.WHITE.R, utils.WHITE.G, utils.WHITE.B, 255)
+	return renderer.DrawRect(&rect)
 }
 
 // Getters and Setters required by the ClickEvent interface
@@ -64,3 +87,28 @@ func (btn *ImageButton) GetHeight() int32 {
 func (btn *ImageButton) RunCallback(i ...interface{}) error {
 	return btn.CallBack(i)
 }
+
+// Methods required by the GestureEvent interface
+func (btn *ImageButton) RunLongPress() (bool, error) {
+	if btn.LongPressCallBack == nil {
+		return false, nil
+	}
+	return true, btn.LongPressCallBack(btn)
+}
+
+func (btn *ImageButton) RunSwipe(direction int) (bool, error) {
+	callBack, ok := btn.SwipeCallBacks[direction]
+	if !ok {
+		return false, nil
+	}
+	return true, callBack(btn)
+}
+
+// Setters required by the PointerStateEvent interface
+func (btn *ImageButton) SetHovered(hovered bool) {
+	btn.Hovered = hovered
+}
+
+func (btn *ImageButton) SetPressed(pressed bool) {
+	btn.Pressed = pressed
+}
@@ -30,6 +30,11 @@ type RectangularButton struct {
 	// The callback function that gets called when the button is clicked. Note that the function
 	// isn't directly called by the EventManager but rather through the RunCallback method
 	CallBack func(...interface{}) error
+
+	// Reports whether the pointer is over the button and whether the button is being pressed. The button
+	// is drawn lighter while hovered and darker while pressed. Both are kept up to date by the EventManager
+	Hovered bool
+	Pressed bool
 }
 
 // Provided Constructor
@@ -55,7 +60,13 @@ func (btn *RectangularButton) Draw(x, y int32, renderer *sdl.Renderer) error {
 		H: btn.Height,
 	}
 
-	_ = renderer.SetDrawColor(btn.Color.R, btn.Color.G, btn.Color.B, btn.Color.A)
+	color := btn.Color
+	if btn.Pressed {
+		color = utils.Tint(btn.Color, -0.25)
+	} else if btn.Hovered {
+		color = utils.Tint(btn.Color, 0.25)
+	}
+	_ = renderer.SetDrawColor(color.R, color.G, color.B, color.A)
 	_ = renderer.FillRect(&rect)
 	textTexture, _ := text.New(btn.BtnText, btn.Font, renderer, sdl.Color{})
 	defer textTexture.Destroy()
@@ -96,3 +107,12 @@ func (btn *RectangularButton) GetHeight() int32 {
 func (btn *RectangularButton) RunCallback(i ...interface{}) error {
 	return btn.CallBack(i)
 }
+
+// Setters required by the PointerStateEvent interface
+func (btn *RectangularButton) SetHovered(hovered bool) {
+	btn.Hovered = hovered
+}
+
+func (btn *RectangularButton) SetPressed(pressed bool) {
+	btn.Pressed = pressed
+}
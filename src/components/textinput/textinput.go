@@ -0,0 +1,194 @@
+// A single line text input. Tapping the input gives it the keyboard, after which the text typed is added to
+// it until return is pressed or another input is tapped. Only one input receives the keyboard at a time,
+// refer to Focused.
+//
+// The input is a ClickEvent so that it can be registered with the event manager like any button. The text
+// events of SDL must be forwarded to the focused input, typically from the main loop:
+//
+//	case *sdl.TextInputEvent:
+//		if input := textinput.Focused(); input != nil {
+//			input.HandleText(t.GetText())
+//		}
+package textinput
+
+import (
+	"CardGameGo/src/components/text"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+	"unicode/utf8"
+)
+
+// The padding between the edge of the input and its text
+const padding = 10
+
+// The input receiving the keyboard, if any
+var focused *TextInput = nil
+
+type TextInput struct {
+	// The text typed so far
+	Text string
+
+	// The text drawn in gray while the input is empty
+	Placeholder string
+
+	// The largest number of characters of the text, or 0 for no limit
+	MaxLength int
+
+	// Check rectbutton.RectangularButton for more details on these attributes
+	Width  int32
+	Height int32
+	X      int32
+	Y      int32
+	Color  *sdl.Color
+	Font   *ttf.Font
+
+	// The function called with the text when return is pressed. Refer to Submit
+	OnSubmit func(text string) error
+}
+
+// Provided constructor
+func New(placeholder string, width, height int32, color *sdl.Color, font *ttf.Font) *TextInput {
+	return &TextInput{
+		Placeholder: placeholder,
+		Width:       width,
+		Height:      height,
+		Color:       color,
+		Font:        font,
+	}
+}
+
+// Returns the input receiving the keyboard, or nil if none does
+func Focused() *TextInput {
+	return focused
+}
+
+// Takes the keyboard away from the focused input, if any, without submitting its text. Useful when the
+// screen showing the input changes
+func Blur() {
+	if focused != nil {
+		focused.Blur()
+	}
+}
+
+// Gives the keyboard to the input, showing the on screen keyboard on mobile devices
+func (in *TextInput) Focus() {
+	if focused == in {
+		return
+	}
+	focused = in
+	sdl.StartTextInput()
+}
+
+// Takes the keyboard away from the input
+func (in *TextInput) Blur() {
+	if focused != in {
+		return
+	}
+	focused = nil
+	sdl.StopTextInput()
+}
+
+// Reports whether the input receives the keyboard
+func (in *TextInput) Focused() bool {
+	return focused == in
+}
+
+// Takes the keyboard away from the input and calls OnSubmit with its text
+func (in *TextInput) Submit() error {
+	in.Blur()
+	if in.OnSubmit == nil {
+		return nil
+	}
+	return in.OnSubmit(in.Text)
+}
+
+// Adds the text of an sdl.TextInputEvent to the input, as long as MaxLength allows
+func (in *TextInput) HandleText(typed string) {
+	if !in.Focused() {
+		return
+	}
+	if in.MaxLength > 0 && utf8.RuneCountInString(in.Text)+utf8.RuneCountInString(typed) > in.MaxLength {
+		return
+	}
+	in.Text += typed
+}
+
+// Handles the keys that edit the text: backspace removes the last character, return submits the text and
+// escape takes the keyboard away from the input
+func (in *TextInput) HandleKey(event *sdl.KeyboardEvent) error {
+	if !in.Focused() || event.Type != sdl.KEYDOWN {
+		return nil
+	}
+
+	switch event.Keysym.Sym {
+	case sdl.K_BACKSPACE:
+		if len(in.Text) > 0 {
+			_, size := utf8.DecodeLastRuneInString(in.Text)
+			in.Text = in.Text[:len(in.Text)-size]
+		}
+	case sdl.K_RETURN:
+		return in.Submit()
+	case sdl.K_ESCAPE:
+		in.Blur()
+	}
+	return nil
+}
+
+// Draws the input with its top left corner at the given position. When the text is wider than the input only
+// its end is drawn, so that the last characters typed are always visible.
+func (in *TextInput) Draw(x, y int32, renderer *sdl.Renderer) error {
+	in.X, in.Y = x, y
+
+	_ = renderer.SetDrawColor(in.Color.R, in.Color.G, in.Color.B, in.Color.A)
+	err := renderer.FillRect(&sdl.Rect{X: x, Y: y, W: in.Width, H: in.Height})
+	if err != nil {
+		return err
+	}
+
+	line, color := in.Text, sdl.Color{A: 255}
+	if in.Focused() {
+		line += "_"
+	} else if line == "" {
+		line, color = in.Placeholder, sdl.Color{R: 128, G: 128, B: 128, A: 255}
+	}
+	if line == "" {
+		return nil
+	}
+
+	texture, err := text.New(line, in.Font, renderer, color)
+	if err != nil {
+		return err
+	}
+	defer texture.Destroy()
+
+	_, _, tW, tH, _ := texture.Query()
+	shown := in.Width - 2*padding
+	if tW < shown {
+		shown = tW
+	}
+	source := &sdl.Rect{X: tW - shown, Y: 0, W: shown, H: tH}
+	return renderer.Copy(texture, source, &sdl.Rect{X: x + padding, Y: y + (in.Height-tH)/2, W: shown, H: tH})
+}
+
+// Getters and setters required by the ClickEvent interface
+func (in *TextInput) GetX() int32 {
+	return in.X
+}
+
+func (in *TextInput) GetY() int32 {
+	return in.Y
+}
+
+func (in *TextInput) GetWidth() int32 {
+	return in.Width
+}
+
+func (in *TextInput) GetHeight() int32 {
+	return in.Height
+}
+
+// Gives the keyboard to the input when it is tapped
+func (in *TextInput) RunCallback(i ...interface{}) error {
+	in.Focus()
+	return nil
+}
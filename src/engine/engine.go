@@ -2,6 +2,7 @@
 // - Provide ways to initialise the SDL library
 // - Loads the different asset managers and the event manager
 // - Unloads the different asset managers when they are no longer being used
+// - Runs the main loop, updating and drawing the scene of every screen. Refer to src/engine/loop.go
 package engine
 
 import (
@@ -15,6 +16,7 @@ import (
 	"github.com/veandco/go-sdl2/ttf"
 	"path/filepath"
 	"runtime"
+	"time"
 )
 
 // Basic constants used for initialisation. Note that these constants do not impact the game screen
@@ -56,11 +58,37 @@ type Engine struct {
 	Sound    *mix.Chunk
 
 	// A variable keeping track of the current screen that is being rendered. The value of this variable
-	// should be one provided by src/screens/screens.go
+	// should be one provided by src/screens/screens.go. Setting it switches to the scene of the new screen
+	// on the next Update or HandleEvent
 	CurrentScreen int
 
+	// The scene drawing every screen, refer to RegisterScene and src/engine/scene.go
+	Scenes map[int]Scene
+
+	// The overlays pushed on top of the current screen, the top one last. Refer to PushScene
+	overlays []int
+
+	// The screen whose scene was last entered, -1 before the first one
+	shownScreen int
+
 	// Indicates whether the application is running
 	Running       bool
+
+	// The number of times per second the scenes are updated, refer to Run
+	UpdateRate int
+
+	// The number of frames drawn per second at most, 0 for no limit besides VSync
+	TargetFPS int
+
+	// Reports whether presenting a frame waits for the display to refresh. Read by Init
+	VSync bool
+
+	// The number of frames drawn per second once no event was received for IdleAfter
+	IdleFPS   int
+	IdleAfter time.Duration
+
+	// The last time an event was received or Wake was called, refer to Idle
+	lastActivity time.Time
 }
 
 // NewEngine returns new engine.
@@ -71,6 +99,13 @@ func New(title string, width, height int32) (e *Engine) {
 
 	e = &Engine{}
 	e.Running = true
+	e.UpdateRate = 30
+	e.TargetFPS = 60
+	e.VSync = true
+	e.IdleFPS = 4
+	e.IdleAfter = 3 * time.Second
+	e.Scenes = make(map[int]Scene)
+	e.shownScreen = -1
 	return
 }
 
@@ -81,6 +116,10 @@ func (e *Engine) Init() (err error) {
 		return
 	}
 
+	// The touches are handled by the event managers themselves, so a mouse must not be reported as a finger
+	// too. SDL reports the fingers as a mouse as well, those events are left out by the scenes
+	sdl.SetHint("SDL_MOUSE_TOUCH_EVENTS", "0")
+
 	e.Font, err = fontmanager.New()
 	if err != nil {
 		return
@@ -101,7 +140,11 @@ func (e *Engine) Init() (err error) {
 		return
 	}
 
-	e.Renderer, err = sdl.CreateRenderer(e.Window, -1, sdl.RENDERER_ACCELERATED)
+	flags := uint32(sdl.RENDERER_ACCELERATED)
+	if e.VSync {
+		flags |= sdl.RENDERER_PRESENTVSYNC
+	}
+	e.Renderer, err = sdl.CreateRenderer(e.Window, -1, flags)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"fmt"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The longest time a single frame accounts for. After a longer pause, for example while the application was
+// in the background, the scenes are not updated for all of the time missed at once
+const maxFrameTime = 250 * time.Millisecond
+
+// Runs the main loop until Quit is called. Every frame:
+//
+//	the events polled from SDL are handled, refer to HandleEvent
+//	the scenes are updated with a fixed dt, as many times as needed to catch up with the time elapsed
+//	the scenes are drawn and presented
+//	the loop waits for the next frame, refer to TargetFPS
+//
+// The scenes are updated UpdateRate times per second whatever the frame rate, so that the game runs the same
+// on every device. The events are passed to onEvent first, which reports whether it handled them; it may be
+// nil. A quit event ends the loop.
+//
+// Once no event was received for IdleAfter, the frame rate drops to IdleFPS to save battery, and the loop
+// wakes up as soon as an event is received. The scenes changing without any input call Wake instead.
+func (e *Engine) Run(onEvent func(event sdl.Event) bool) error {
+	step := time.Second / time.Duration(e.UpdateRate)
+	e.lastActivity = time.Now()
+
+	previous := time.Now()
+	var lag time.Duration
+	for e.Running {
+		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
+			e.handle(event, onEvent)
+		}
+
+		now := time.Now()
+		elapsed := now.Sub(previous)
+		previous = now
+		if elapsed > maxFrameTime {
+			elapsed = maxFrameTime
+		}
+		lag += elapsed
+
+		err := e.showScene()
+		if err != nil {
+			return err
+		}
+		for lag >= step {
+			err = e.Update(step)
+			if err != nil {
+				return err
+			}
+			lag -= step
+		}
+
+		err = e.Draw()
+		if err != nil {
+			return err
+		}
+		e.Renderer.Present()
+
+		e.wait(now, onEvent)
+	}
+	return nil
+}
+
+// Reports whether no event was received and Wake was not called for IdleAfter
+func (e *Engine) Idle() bool {
+	return time.Since(e.lastActivity) > e.IdleAfter
+}
+
+// Keeps the main loop at TargetFPS for another IdleAfter, as if an event was received
+func (e *Engine) Wake() {
+	e.lastActivity = time.Now()
+}
+
+// Passes the event to onEvent and then to the scene on top of the stack
+func (e *Engine) handle(event sdl.Event, onEvent func(event sdl.Event) bool) {
+	e.lastActivity = time.Now()
+
+	if _, ok := event.(*sdl.QuitEvent); ok {
+		e.Quit()
+		return
+	}
+	if onEvent != nil && onEvent(event) {
+		return
+	}
+	err := e.HandleEvent(event)
+	if err != nil {
+		fmt.Printf("ignoring event %q: %d\n", err, event.GetTimestamp())
+	}
+}
+
+// Waits for the next frame of the frame rate, TargetFPS or IdleFPS, to be due. While idle, the wait ends as
+// soon as an event is received
+func (e *Engine) wait(frameStart time.Time, onEvent func(event sdl.Event) bool) {
+	fps := e.TargetFPS
+	if e.Idle() {
+		fps = e.IdleFPS
+	}
+	if fps <= 0 {
+		return
+	}
+
+	remaining := time.Second/time.Duration(fps) - time.Since(frameStart)
+	if remaining <= 0 {
+		return
+	}
+	if !e.Idle() {
+		sdl.Delay(uint32(remaining / time.Millisecond))
+		return
+	}
+	if event := sdl.WaitEventTimeout(int(remaining / time.Millisecond)); event != nil {
+		e.handle(event, onEvent)
+	}
+}
@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// A screen of the application. Every screen of src/screens/screen.go is drawn by the scene registered for it,
+// refer to RegisterScene. The engine calls the hooks of a scene in the following order:
+//
+//	OnEnter when the scene is shown, either as the current screen or pushed as an overlay
+//	Update, HandleEvent and Draw, every frame for as long as it is shown
+//	OnExit when it is left, after which the event manager of its screen is cleared
+//
+// A scene keeps its own state and widgets, it registers its widgets with the event manager of its screen
+// in OnEnter.
+type Scene interface {
+	// Called when the scene is shown
+	OnEnter() error
+
+	// Called when the scene is left, either for another screen or popped off the scene stack
+	OnExit() error
+
+	// Moves the scene forward by dt, the time elapsed since the previous update. The main loop always
+	// passes the same dt, refer to Engine.Run
+	Update(dt time.Duration) error
+
+	// Draws the scene with the renderer of the engine. Overlays are drawn on top of the scenes below them
+	Draw() error
+
+	// Handles an event polled from SDL. Only the scene on top of the stack receives the events
+	HandleEvent(event sdl.Event) error
+}
+
+// Registers the scene drawing the screen, one of src/screens/screen.go
+func (e *Engine) RegisterScene(screen int, scene Scene) {
+	e.Scenes[screen] = scene
+}
+
+// Pushes the scene of the screen on top of the current screen, typically an overlay such as the pause
+// menu. The scenes below keep being updated and drawn but no longer receive events until it is popped.
+func (e *Engine) PushScene(screen int) error {
+	scene, ok := e.Scenes[screen]
+	if !ok {
+		return errors.New(fmt.Sprintf("scene error: no scene for screen %d", screen))
+	}
+	e.overlays = append(e.overlays, screen)
+	return scene.OnEnter()
+}
+
+// Pops the overlay on top of the scene stack, giving the events back to the scene below it
+func (e *Engine) PopScene() error {
+	if len(e.overlays) == 0 {
+		return errors.New("scene error: no overlay to pop")
+	}
+	screen := e.overlays[len(e.overlays)-1]
+	e.overlays = e.overlays[:len(e.overlays)-1]
+	return e.exit(screen)
+}
+
+// Returns the screen on top of the scene stack, which receives the events
+func (e *Engine) TopScreen() int {
+	if len(e.overlays) > 0 {
+		return e.overlays[len(e.overlays)-1]
+	}
+	return e.CurrentScreen
+}
+
+// Reports whether the screen is shown, either as the current screen or as an overlay on top of it
+func (e *Engine) Shows(screen int) bool {
+	if screen == e.CurrentScreen {
+		return true
+	}
+	for _, overlay := range e.overlays {
+		if overlay == screen {
+			return true
+		}
+	}
+	return false
+}
+
+// Updates every scene of the stack, from the bottom up. A change of CurrentScreen, made by a scene or by one
+// of its widgets, is applied first: the overlays are popped, the scene left exits and the new one enters.
+// The fingers held on the widgets of the top scene long press them once they were held long enough.
+func (e *Engine) Update(dt time.Duration) error {
+	err := e.showScene()
+	if err != nil {
+		return err
+	}
+	err = e.Event[e.TopScreen()].ProcessLongPresses()
+	if err != nil {
+		fmt.Printf("ignoring long press %q\n", err)
+	}
+	for _, screen := range e.stack() {
+		err = e.Scenes[screen].Update(dt)
+		if err != nil {
+			return err
+		}
+	}
+	return e.showScene()
+}
+
+// Draws every scene of the stack, from the bottom up
+func (e *Engine) Draw() error {
+	for _, screen := range e.stack() {
+		err := e.Scenes[screen].Draw()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Passes the event to the scene on top of the stack
+func (e *Engine) HandleEvent(event sdl.Event) error {
+	scene, ok := e.Scenes[e.TopScreen()]
+	if !ok {
+		return nil
+	}
+	err := scene.HandleEvent(event)
+	if err != nil {
+		return err
+	}
+	return e.showScene()
+}
+
+// Returns the screens of the scene stack, the current screen first
+func (e *Engine) stack() []int {
+	return append([]int{e.CurrentScreen}, e.overlays...)
+}
+
+// Swaps the scenes when CurrentScreen changed since the last call
+func (e *Engine) showScene() error {
+	if e.CurrentScreen == e.shownScreen {
+		return nil
+	}
+	e.Wake()
+
+	for len(e.overlays) > 0 {
+		err := e.PopScene()
+		if err != nil {
+			return err
+		}
+	}
+	if e.shownScreen >= 0 {
+		err := e.exit(e.shownScreen)
+		if err != nil {
+			return err
+		}
+	}
+
+	e.shownScreen = e.CurrentScreen
+	scene, ok := e.Scenes[e.shownScreen]
+	if !ok {
+		return errors.New(fmt.Sprintf("scene error: no scene for screen %d", e.shownScreen))
+	}
+	return scene.OnEnter()
+}
+
+// Lets the scene of the screen exit and clears the events it registered
+func (e *Engine) exit(screen int) error {
+	defer e.Event[screen].Clear()
+	if scene, ok := e.Scenes[screen]; ok {
+		return scene.OnExit()
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+// Ties a game variant, the deck and the rules engine together into the flow of a single hand: the deal, the
+// optional bidding phase and the trick taking phase. A Game is the complete state of the hand being played,
+// which makes it the object that the user interface draws, that computer players reason about and that
+// headless tools drive.
+//
+// Like the rules engine, this package must never import SDL.
+package game
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"errors"
+	"fmt"
+)
+
+type Phase int
+
+const (
+	// The cards have not been dealt yet
+	Dealing Phase = iota
+
+	// Every seat bids in turn. Only used by variants with a bidding phase
+	Bidding
+
+	// The tricks are being played
+	Playing
+
+	// Every trick has been played and collected
+	HandOver
+)
+
+func (p Phase) String() string {
+	switch p {
+	case Dealing:
+		return "Dealing"
+	case Bidding:
+		return "Bidding"
+	case Playing:
+		return "Playing"
+	case HandOver:
+		return "Hand Over"
+	default:
+		return ""
+	}
+}
+
+type Game struct {
+	// The game being played
+	Variant variants.Variant
+
+	// The seed used to shuffle the deck. Dealing twice with the same seed, dealer and variant produces the
+	// exact same hands
+	Seed int64
+
+	// The seat that deals the hand
+	Dealer int
+
+	Phase Phase
+
+	// The trump suit of the hand or cards.NoSuit for a hand without trumps
+	Trump cards.Suit
+
+	// The hands as they were dealt, keyed by direction. These are never modified once dealt, the cards
+	// currently held by every seat are found in the Table
+	Dealt map[int][]cards.Card
+
+	// The bidding round of the hand. Nil for variants without a bidding phase
+	Auction *rules.Auction
+
+	// The trick taking state of the hand. Nil until the bidding phase is over
+	Table *rules.Table
+}
+
+// Provided constructor. A zero seed picks a new random seed when the cards are dealt.
+func New(variant variants.Variant, dealer int, seed int64) *Game {
+	return &Game{
+		Variant: variant,
+		Seed:    seed,
+		Dealer:  dealer,
+		Phase:   Dealing,
+	}
+}
+
+// Shuffles the variant's deck with the game's seed and deals a hand to every seat in directions.Order,
+// starting with North. The game then moves on to the bidding phase, or straight to the playing phase for
+// variants without bidding.
+func (g *Game) Deal() error {
+	if g.Seed == 0 {
+		g.Seed = cards.NewSeed()
+	}
+
+	deck := g.Variant.NewDeck()
+	deck.Shuffle(g.Seed)
+	hands, err := deck.Deal(len(directions.Order), g.Variant.HandSize())
+	if err != nil {
+		return err
+	}
+
+	g.Dealt = make(map[int][]cards.Card)
+	for i, seat := range directions.Order {
+		g.Dealt[seat] = hands[i]
+	}
+	g.Trump = g.Variant.ChooseTrump(g.Dealt, g.Dealer)
+	g.Table = nil
+
+	g.Auction = variants.NewAuction(g.Variant, g.Dealer)
+	if g.Auction != nil {
+		g.Phase = Bidding
+	} else {
+		g.startPlaying()
+	}
+	return nil
+}
+
+func (g *Game) startPlaying() {
+	g.Table = variants.NewTable(g.Variant, g.Dealt, g.Dealer)
+	g.Table.Trump = g.Trump
+	g.Phase = Playing
+}
+
+// Returns the seat whose turn it is to bid or play
+func (g *Game) Current() int {
+	switch g.Phase {
+	case Bidding:
+		return g.Auction.Current
+	case Playing, HandOver:
+		return g.Table.Current
+	default:
+		return g.Dealer
+	}
+}
+
+// Returns the cards currently held by the seat
+func (g *Game) Hand(seat int) []cards.Card {
+	if g.Table != nil {
+		return g.Table.Hands[seat]
+	}
+	return g.Dealt[seat]
+}
+
+// Returns the bid of the seat and whether the seat has bid yet
+func (g *Game) BidOf(seat int) (int, bool) {
+	if g.Auction == nil {
+		return 0, false
+	}
+	return g.Auction.BidOf(seat)
+}
+
+// Returns the bids the seat may make right now
+func (g *Game) LegalBids(seat int) []int {
+	if g.Phase != Bidding {
+		return nil
+	}
+	return g.Auction.LegalBids(seat)
+}
+
+// Records a bid for the seat. Once every seat has bid the game moves on to the playing phase.
+func (g *Game) Bid(seat, bid int) error {
+	if g.Phase != Bidding {
+		return errors.New(fmt.Sprintf("bid error: cannot bid while %s", g.Phase))
+	}
+
+	err := g.Auction.Bid(seat, bid)
+	if err != nil {
+		return err
+	}
+	if g.Auction.Complete() {
+		g.startPlaying()
+	}
+	return nil
+}
+
+// Returns the cards the seat may play right now
+func (g *Game) LegalMoves(seat int) []cards.Card {
+	if g.Phase != Playing {
+		return nil
+	}
+	return g.Table.LegalMoves(seat)
+}
+
+// Plays a card for the seat. Refer to rules.Table.Play for more info
+func (g *Game) Play(seat int, card cards.Card) error {
+	if g.Phase != Playing {
+		return errors.New(fmt.Sprintf("play error: cannot play while %s", g.Phase))
+	}
+	return g.Table.Play(seat, card)
+}
+
+// Collects the complete trick and returns its winner. Once the last trick is collected the game moves on to
+// the hand over phase.
+func (g *Game) Collect() (int, error) {
+	if g.Phase != Playing {
+		return 0, errors.New(fmt.Sprintf("collect error: cannot collect while %s", g.Phase))
+	}
+
+	winner, err := g.Table.Collect()
+	if err != nil {
+		return 0, err
+	}
+	if g.Table.HandOver() {
+		g.Phase = HandOver
+	}
+	return winner, nil
+}
+
+// Overrides the trump chosen by the variant. The trump can only be changed before the first card is played.
+func (g *Game) SetTrump(trump cards.Suit) error {
+	if g.Table != nil && (len(g.Table.Tricks) > 0 || len(g.Table.Trick.Plays) > 0) {
+		return errors.New("trump error: cannot change the trump once the hand has started")
+	}
+	g.Trump = trump
+	if g.Table != nil {
+		g.Table.Trump = trump
+	}
+	return nil
+}
+
+// Returns the outcome of the hand so far, as expected by variants.Variant.ScoreHand
+func (g *Game) Result() variants.HandResult {
+	result := variants.HandResult{Dealer: g.Dealer}
+	if g.Table != nil {
+		result.TricksWon = g.Table.TricksWon
+		result.Tricks = g.Table.Tricks
+	}
+	if g.Auction != nil {
+		result.Bids = g.Auction.Bids
+	}
+	return result
+}
+
+// Returns a copy of the game that can be played on without affecting the original. The dealt hands are
+// shared since they are never modified.
+func (g *Game) Clone() *Game {
+	clone := *g
+	if g.Auction != nil {
+		clone.Auction = g.Auction.Clone()
+	}
+	if g.Table != nil {
+		clone.Table = g.Table.Clone()
+	}
+	return &clone
+}
+
+// Returns a copy of the game as seen from the seat. It holds everything that is public (the bids, the cards
+// played, the tricks) but only the cards of the seat, the other hands being left empty. The seed is dropped
+// as well since it would reveal every hand. A seat that does not play, such as a spectator, sees no cards.
+func (g *Game) View(seat int) *Game {
+	view := g.Clone()
+	view.Seed = 0
+	if g.Dealt != nil {
+		view.Dealt = make(map[int][]cards.Card)
+		if hand, ok := g.Dealt[seat]; ok {
+			view.Dealt[seat] = hand
+		}
+	}
+	if view.Table != nil {
+		for other := range view.Table.Hands {
+			if other != seat {
+				delete(view.Table.Hands, other)
+			}
+		}
+	}
+	return view
+}
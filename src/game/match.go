@@ -0,0 +1,98 @@
+package game
+
+import (
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"errors"
+)
+
+// A match is a series of hands of the same variant played until one team reaches the variant's target. The
+// deal moves to the next seat after every hand.
+type Match struct {
+	Variant variants.Variant
+
+	// The seed of the match. The seed of every hand is derived from it so that a whole match can be
+	// reproduced from a single seed
+	Seed int64
+
+	// The hand being played
+	Hand *Game
+
+	// The score of every hand played so far and the running totals. Refer to src/scoring for more info
+	Score *scoring.Match
+
+	// Reports whether the current hand has already been added to the score
+	scored bool
+}
+
+// Provided constructor. The first hand is dealt by the given seat. A zero seed picks a new random seed.
+func NewMatch(variant variants.Variant, dealer int, seed int64) *Match {
+	return &Match{
+		Variant: variant,
+		Seed:    seed,
+		Hand:    New(variant, dealer, 0),
+		Score:   scoring.NewMatch(variant.Teams()),
+	}
+}
+
+// Deals the first hand of the match
+func (m *Match) Start() error {
+	m.Hand.Seed = m.handSeed()
+	m.scored = false
+	err := m.Hand.Deal()
+	if err != nil {
+		return err
+	}
+	if m.Seed == 0 {
+		m.Seed = m.Hand.Seed
+	}
+	return nil
+}
+
+// Returns the seed of the hand about to be dealt
+func (m *Match) handSeed() int64 {
+	if m.Seed == 0 {
+		return 0
+	}
+	return m.Seed + int64(len(m.Score.Hands))
+}
+
+// Scores the current hand once every trick has been collected and adds it to the match. Calling it again
+// for the same hand returns the score that was already recorded.
+func (m *Match) EndHand() (scoring.HandScore, error) {
+	if m.Hand.Phase != HandOver {
+		return scoring.HandScore{}, errors.New("score error: the hand is not over")
+	}
+	if m.scored {
+		return m.Score.Hands[len(m.Score.Hands)-1], nil
+	}
+
+	score := m.Variant.ScoreHand(m.Hand.Result(), m.Score)
+	m.Score.Record(score)
+	m.scored = true
+	return score, nil
+}
+
+// Scores the current hand if needed and deals the next one, with the deal passing to the next seat
+func (m *Match) NextHand() error {
+	if _, over := m.Over(); over {
+		return errors.New("deal error: the match is over")
+	}
+	_, err := m.EndHand()
+	if err != nil {
+		return err
+	}
+
+	m.Hand = New(m.Variant, directions.Next(m.Hand.Dealer), m.handSeed())
+	m.scored = false
+	return m.Hand.Deal()
+}
+
+// Reports whether the match is over and if so which team won. Refer to variants.Variant.MatchOver
+func (m *Match) Over() (winner int, over bool) {
+	if len(m.Score.Hands) == 0 {
+		return 0, false
+	}
+	return m.Variant.MatchOver(m.Score.Totals)
+}
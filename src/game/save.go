@@ -0,0 +1,114 @@
+package game
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/variants"
+	"errors"
+	"fmt"
+)
+
+// The complete state of a match in a form that can be written to a file, for example as JSON, and read back
+// to resume the match exactly where it was left. Unlike the state sent to the clients of a game, it holds
+// every hand and the seeds. Refer to Match.Snapshot and Snapshot.Match
+type Snapshot struct {
+	Variant string `json:"variant"`
+
+	// The seed of the match and the seed of the hand being played
+	Seed     int64 `json:"seed"`
+	HandSeed int64 `json:"handSeed"`
+
+	Dealer int        `json:"dealer"`
+	Phase  Phase      `json:"phase"`
+	Trump  cards.Suit `json:"trump"`
+
+	// The seat whose turn it is to bid or play
+	Current int `json:"current"`
+
+	// The hands as they were dealt and the cards still held by every seat, keyed by direction
+	Dealt map[int][]cards.Card `json:"dealt"`
+	Hands map[int][]cards.Card `json:"hands"`
+
+	// The bids made so far, keyed by direction. Nil for variants without a bidding phase
+	Bids map[int]int `json:"bids,omitempty"`
+
+	// The trick being played, the tricks already collected and the number of tricks won by every seat
+	Trick     rules.Trick   `json:"trick"`
+	Tricks    []rules.Trick `json:"tricks"`
+	TricksWon map[int]int   `json:"tricksWon"`
+
+	// The score of the match, and whether the current hand is already part of it
+	Score  *scoring.Match `json:"score"`
+	Scored bool           `json:"scored"`
+}
+
+// Returns the complete state of the match. The snapshot shares the slices and maps of the match, it must be
+// written out before the match changes again.
+func (m *Match) Snapshot() *Snapshot {
+	g := m.Hand
+	snapshot := &Snapshot{
+		Variant:  m.Variant.Name(),
+		Seed:     m.Seed,
+		HandSeed: g.Seed,
+		Dealer:   g.Dealer,
+		Phase:    g.Phase,
+		Trump:    g.Trump,
+		Current:  g.Current(),
+		Dealt:    g.Dealt,
+		Score:    m.Score,
+		Scored:   m.scored,
+	}
+	if g.Auction != nil {
+		snapshot.Bids = g.Auction.Bids
+	}
+	if g.Table != nil {
+		snapshot.Hands = g.Table.Hands
+		snapshot.Trick = g.Table.Trick
+		snapshot.Tricks = g.Table.Tricks
+		snapshot.TricksWon = g.Table.TricksWon
+	}
+	return snapshot
+}
+
+// Rebuilds the match the snapshot was taken from, ready to be played on
+func (s *Snapshot) Match() (*Match, error) {
+	variant, ok := variants.ByName(s.Variant)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("snapshot error: unknown variant %q", s.Variant))
+	}
+	if s.Phase != Dealing && len(s.Dealt) == 0 {
+		return nil, errors.New("snapshot error: the hands are missing")
+	}
+
+	g := New(variant, s.Dealer, s.HandSeed)
+	g.Phase = s.Phase
+	g.Trump = s.Trump
+	g.Dealt = s.Dealt
+
+	if s.Phase != Dealing {
+		if g.Auction = variants.NewAuction(variant, s.Dealer); g.Auction != nil {
+			for seat, bid := range s.Bids {
+				g.Auction.Bids[seat] = bid
+			}
+			g.Auction.Current = s.Current
+		}
+	}
+
+	if s.Phase == Playing || s.Phase == HandOver {
+		g.Table = variants.NewTable(variant, s.Hands, s.Dealer)
+		g.Table.Trump = s.Trump
+		g.Table.Current = s.Current
+		g.Table.Trick = s.Trick
+		g.Table.Tricks = s.Tricks
+		for seat, won := range s.TricksWon {
+			g.Table.TricksWon[seat] = won
+		}
+	}
+
+	score := s.Score
+	if score == nil {
+		score = scoring.NewMatch(variant.Teams())
+	}
+	return &Match{Variant: variant, Seed: s.Seed, Hand: g, Score: score, scored: s.Scored}, nil
+}
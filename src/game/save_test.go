@@ -0,0 +1,71 @@
+package game
+
+import (
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Plays the first legal action of the seat whose turn it is, collecting the complete tricks
+func step(t *testing.T, g *Game) {
+	var err error
+	switch {
+	case g.Phase == Bidding:
+		err = g.Bid(g.Current(), g.LegalBids(g.Current())[0])
+	case g.Table.TrickComplete():
+		_, err = g.Collect()
+	default:
+		err = g.Play(g.Current(), g.LegalMoves(g.Current())[0])
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSnapshotResumesTheMatch(t *testing.T) {
+	for _, variant := range variants.All {
+		match := NewMatch(variant, directions.East, 42)
+		err := match.Start()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 10; i++ {
+			step(t, match.Hand)
+		}
+
+		data, err := json.Marshal(match.Snapshot())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var snapshot Snapshot
+		err = json.Unmarshal(data, &snapshot)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resumed, err := snapshot.Match()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Both matches must carry on exactly the same way until the end of the hand
+		for match.Hand.Phase != HandOver {
+			g, r := match.Hand, resumed.Hand
+			if r.Phase != g.Phase || r.Current() != g.Current() || !reflect.DeepEqual(r.Result(), g.Result()) {
+				t.Fatalf("%s: the resumed match differs from the original", variant.Name())
+			}
+			for _, seat := range directions.Order {
+				if !reflect.DeepEqual(r.Hand(seat), g.Hand(seat)) {
+					t.Fatalf("%s: the hand of %s differs", variant.Name(), directions.String(seat))
+				}
+			}
+			step(t, g)
+			step(t, r)
+		}
+
+		if resumed.Hand.Phase != HandOver || resumed.Seed != match.Seed || resumed.Hand.Seed != match.Hand.Seed {
+			t.Fatalf("%s: the resumed match ended differently", variant.Name())
+		}
+	}
+}
@@ -5,10 +5,21 @@
 // The event manager currently only supports ClickEvents but can be easily extended to support other types
 // of events in the future. The events are added in a stack like manner and our processed in a LIFO order.
 // This is done to give precedence to newly added events which feels more intuitive during game development.
+//
+// A click fires when the mouse button is released over the event it was pressed on, so that sliding off a
+// button cancels the click. The fingers on a touch screen tap the events the same way, and may also long press
+// or swipe them, refer to src/managers/eventmanager/touch/touch.go. The events implementing
+// events.PointerStateEvent are told when the pointer is over them and while they are pressed, so that they can
+// be drawn accordingly.
+//
+// Events are registered once rather than every frame. Registering returns a Handle that removes the event
+// again, registering an event twice is a no-op, and Clear removes every event of a screen when the screen is
+// left so that the screen can register its events again the next time it is shown.
 package eventmanager
 
 import (
 	"CardGameGo/src/managers/eventmanager/events"
+	"CardGameGo/src/managers/eventmanager/touch"
 	"github.com/veandco/go-sdl2/sdl"
 )
 
@@ -22,33 +33,176 @@ type EventManager struct {
 	// the need for upcoming events are a useful insight into app level state. Perhaps in the
 	// future a getter can be provided to provide more control over the process.
 	RegisteredClicks []events.ClickEvent
+
+	// The handle of every registered event, in the same order as RegisteredClicks
+	handles []Handle
+
+	// The handle given to the next event registered
+	next Handle
+
+	// The event the mouse button was pressed on, fired if it is released over it, and the event the pointer
+	// is over. Either may be nil
+	pressed events.ClickEvent
+	hovered events.ClickEvent
+
+	// The fingers on the screen, refer to ProcessTouchEvents
+	fingers *touch.Tracker
 }
 
+// Identifies a registered event so that it can be unregistered. Handles are never reused by an EventManager
+type Handle int
+
 // Provided constructor
 func New(screen int) *EventManager {
-	return &EventManager{
+	em := &EventManager{
 		screen:           screen,
 		RegisteredClicks: make([]events.ClickEvent, 0, 5),
+		handles:          make([]Handle, 0, 5),
+		next:             1,
 	}
+	em.fingers = touch.NewTracker(em.at)
+	return em
 }
 
-// Registers a ClickEvent object as an event provider for this particular screen.
-func (em *EventManager) RegisterEvent(event events.ClickEvent) {
+// Registers a ClickEvent object as an event provider for this particular screen and returns the handle that
+// unregisters it. An event that is already registered keeps its place, and its handle is returned again.
+func (em *EventManager) RegisterEvent(event events.ClickEvent) Handle {
+	if i := em.indexOf(event); i >= 0 {
+		return em.handles[i]
+	}
+
+	handle := em.next
+	em.next++
 	em.RegisteredClicks = append(em.RegisteredClicks, event)
+	em.handles = append(em.handles, handle)
+	return handle
+}
+
+// Removes the event registered with the handle. Reports whether the event was still registered
+func (em *EventManager) Unregister(handle Handle) bool {
+	for i, h := range em.handles {
+		if h == handle {
+			em.remove(i)
+			return true
+		}
+	}
+	return false
+}
+
+// Removes the event, whatever handle it was registered with. Reports whether the event was registered
+func (em *EventManager) UnregisterEvent(event events.ClickEvent) bool {
+	i := em.indexOf(event)
+	if i < 0 {
+		return false
+	}
+	em.remove(i)
+	return true
+}
+
+// Removes every event of the screen, typically when the screen is left
+func (em *EventManager) Clear() {
+	em.setPressed(nil)
+	em.setHovered(nil)
+	em.fingers.Release()
+	em.RegisteredClicks = em.RegisteredClicks[:0]
+	em.handles = em.handles[:0]
+}
+
+// Returns the number of events registered
+func (em *EventManager) Len() int {
+	return len(em.RegisteredClicks)
+}
+
+// Returns the index of the event in RegisteredClicks, or -1 if it is not registered
+func (em *EventManager) indexOf(event events.ClickEvent) int {
+	for i, e := range em.RegisteredClicks {
+		if e == event {
+			return i
+		}
+	}
+	return -1
 }
 
-// Process and fires an event out of all the registered events of this screen. This method
-// simply iterates (in reverse order) over all the objects and fires the *most recently*
-// added ClickEvent. It does so by comparing the mouse position at the time of click with
-// the the object positions of all the objects in the RegisteredClick slice. Since the scan
-// is linear, it is best to divide events into multiple screens and scan accordingly
+func (em *EventManager) remove(i int) {
+	if em.RegisteredClicks[i] == em.pressed {
+		em.setPressed(nil)
+	}
+	if em.RegisteredClicks[i] == em.hovered {
+		em.setHovered(nil)
+	}
+	em.fingers.Forget(em.RegisteredClicks[i])
+	em.RegisteredClicks = append(em.RegisteredClicks[:i], em.RegisteredClicks[i+1:]...)
+	em.handles = append(em.handles[:i], em.handles[i+1:]...)
+}
+
+// Processes a press or a release of the left mouse button. A press only marks the *most recently* added
+// ClickEvent under the mouse as pressed, its callback is fired once the button is released over it. A release
+// anywhere else cancels the click. Since the scan is linear, it is best to divide events into multiple screens
+// and scan accordingly
 func (em *EventManager) ProcessClickEvents(mouseEv *sdl.MouseButtonEvent) error {
+	if mouseEv.Button != sdl.BUTTON_LEFT {
+		return nil
+	}
+
+	e := em.at(mouseEv.X, mouseEv.Y)
+	if mouseEv.Type == sdl.MOUSEBUTTONDOWN {
+		em.setPressed(e)
+		return nil
+	}
+
+	pressed := em.pressed
+	em.setPressed(nil)
+	if e == nil || e != pressed {
+		return nil
+	}
+	return e.RunCallback(e)
+}
+
+// Processes a move of the mouse, updating the event the pointer is over. The event pressed is only drawn
+// pressed while the pointer is over it, as the click is cancelled if the button is released elsewhere
+func (em *EventManager) ProcessMotionEvents(motionEv *sdl.MouseMotionEvent) {
+	e := em.at(motionEv.X, motionEv.Y)
+	em.setHovered(e)
+	if state, ok := em.pressed.(events.PointerStateEvent); ok {
+		state.SetPressed(e == em.pressed)
+	}
+}
+
+// Returns the *most recently* added ClickEvent at the given position, or nil if there is none. It does so by
+// comparing the position with the object positions of all the objects in the RegisteredClick slice, in
+// reverse order
+func (em *EventManager) at(x, y int32) events.ClickEvent {
 	for i := len(em.RegisteredClicks) - 1; i >= 0; i-- {
 		e := em.RegisteredClicks[i]
-		if mouseEv.X >= e.GetX() && mouseEv.X <= (e.GetX()+e.GetWidth()) &&
-			mouseEv.Y >= e.GetY() && mouseEv.Y <= (e.GetY()+e.GetHeight()) {
-			return e.RunCallback(e)
+		if x >= e.GetX() && x <= (e.GetX()+e.GetWidth()) &&
+			y >= e.GetY() && y <= (e.GetY()+e.GetHeight()) {
+			return e
 		}
 	}
 	return nil
 }
+
+// Marks the event as pressed, and the one pressed before it as released. The event may be nil
+func (em *EventManager) setPressed(e events.ClickEvent) {
+	if state, ok := em.pressed.(events.PointerStateEvent); ok {
+		state.SetPressed(false)
+	}
+	em.pressed = e
+	if state, ok := e.(events.PointerStateEvent); ok {
+		state.SetPressed(true)
+	}
+}
+
+// Marks the event as hovered, and the one hovered before it as not hovered anymore. The event may be nil
+func (em *EventManager) setHovered(e events.ClickEvent) {
+	if e == em.hovered {
+		return
+	}
+	if state, ok := em.hovered.(events.PointerStateEvent); ok {
+		state.SetHovered(false)
+	}
+	em.hovered = e
+	if state, ok := e.(events.PointerStateEvent); ok {
+		state.SetHovered(true)
+	}
+}
@@ -0,0 +1,18 @@
+package events
+
+// The directions a finger can be swiped in
+const (
+	SwipeUp = iota
+	SwipeDown
+	SwipeLeft
+	SwipeRight
+)
+
+// The interface implemented by the components reacting to gestures made with a finger on a touch screen. Both
+// methods report whether the component reacts to the gesture, the gestures it ignores are handled like a tap.
+// For more information refer to src/managers/eventmanager/touch/touch.go
+type GestureEvent interface {
+	ClickEvent
+	RunLongPress() (bool, error)
+	RunSwipe(direction int) (bool, error)
+}
@@ -0,0 +1,10 @@
+package events
+
+// The interface implemented by the components that are drawn differently while the pointer is over them or
+// while they are being pressed. The event manager keeps their state up to date as the mouse moves and its
+// button goes down and up. For more information refer to src/managers/eventmanager/eventmanager.go
+type PointerStateEvent interface {
+	ClickEvent
+	SetHovered(hovered bool)
+	SetPressed(pressed bool)
+}
@@ -0,0 +1,30 @@
+package eventmanager
+
+import (
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Processes a finger touching, moving on or leaving the screen, refer to touch.Tracker. The long presses are
+// run by ProcessLongPresses.
+//
+// The position of the fingers is normalized by SDL, it is mapped to the given size of the window.
+func (em *EventManager) ProcessTouchEvents(touchEv *sdl.TouchFingerEvent, w, h int32) error {
+	x, y := int32(touchEv.X*float32(w)), int32(touchEv.Y*float32(h))
+	id := int64(touchEv.FingerID)
+
+	switch touchEv.Type {
+	case sdl.FINGERDOWN:
+		em.fingers.Down(id, x, y)
+	case sdl.FINGERMOTION:
+		em.fingers.Move(id, x, y)
+	case sdl.FINGERUP:
+		return em.fingers.Up(id, x, y)
+	}
+	return nil
+}
+
+// Runs the long press of the events held down by a finger for touch.LongPressDuration. Meant to be called
+// every frame, since nothing else happens while a finger is held still
+func (em *EventManager) ProcessLongPresses() error {
+	return em.fingers.RunLongPresses()
+}
@@ -0,0 +1,195 @@
+package touch
+
+import (
+	"CardGameGo/src/managers/eventmanager/events"
+	"time"
+)
+
+// How long a finger must be held still on an event for a long press
+const LongPressDuration = 500 * time.Millisecond
+
+// How far, in pixels, a finger may move and still tap or long press the event it touched
+const TouchSlop = 20
+
+// How far a finger must move, in pixels, and how fast for a swipe
+const SwipeDistance = 100
+const SwipeDuration = 600 * time.Millisecond
+
+// A finger on the screen
+type finger struct {
+	// Where the finger touched the screen, when, and the event it touched there, if any
+	x, y   int32
+	start  time.Time
+	target events.ClickEvent
+
+	// Reports whether the finger moved further than TouchSlop, in which case it does not long press
+	moved bool
+
+	// Reports whether a long press was already run for the finger, in which case it does not tap either
+	done bool
+
+	// Reports whether the target was asked to handle a long press already
+	held bool
+
+	// Reports whether the finger presses its target, refer to press
+	pressing bool
+}
+
+// Follows the fingers on a touch screen. Every finger is followed on its own, so that several events can be
+// pressed at once. A finger leaving the screen over the event it touched taps it, like a click, unless it was
+// swiped across it, refer to events.GestureEvent. A finger held still on an event long presses it.
+//
+// The tracker knows nothing of SDL, the event manager feeds it the fingers in pixels, refer to
+// src/managers/eventmanager/touch.go
+type Tracker struct {
+	// Returns the event at the given position, or nil if there is none
+	At func(x, y int32) events.ClickEvent
+
+	// Returns the current time, time.Now unless replaced
+	Now func() time.Time
+
+	fingers map[int64]*finger
+}
+
+// Provided constructor
+func NewTracker(at func(x, y int32) events.ClickEvent) *Tracker {
+	return &Tracker{
+		At:      at,
+		Now:     time.Now,
+		fingers: make(map[int64]*finger),
+	}
+}
+
+// Processes a finger touching the screen, pressing the event under it
+func (t *Tracker) Down(id int64, x, y int32) {
+	f := &finger{x: x, y: y, start: t.Now(), target: t.At(x, y)}
+	t.fingers[id] = f
+	t.press(f, true)
+}
+
+// Processes a finger moving on the screen. Its target is only drawn pressed while the finger is over it
+func (t *Tracker) Move(id int64, x, y int32) {
+	f, ok := t.fingers[id]
+	if !ok {
+		return
+	}
+	if abs(x-f.x) > TouchSlop || abs(y-f.y) > TouchSlop {
+		f.moved = true
+	}
+	t.press(f, !f.done && t.At(x, y) == f.target)
+}
+
+// Processes a finger leaving the screen, which swipes or taps the event it touched
+func (t *Tracker) Up(id int64, x, y int32) error {
+	f, ok := t.fingers[id]
+	if !ok {
+		return nil
+	}
+	t.press(f, false)
+	delete(t.fingers, id)
+	if f.done || f.target == nil {
+		return nil
+	}
+
+	if direction, ok := f.swipe(x, y, t.Now()); ok {
+		if gesture, ok := f.target.(events.GestureEvent); ok {
+			handled, err := gesture.RunSwipe(direction)
+			if handled || err != nil {
+				return err
+			}
+		}
+	}
+	if t.At(x, y) == f.target {
+		return f.target.RunCallback(f.target)
+	}
+	return nil
+}
+
+// Runs the long press of the events held down by a finger for LongPressDuration. Meant to be called every
+// frame, since nothing else happens while a finger is held still
+func (t *Tracker) RunLongPresses() error {
+	for _, f := range t.fingers {
+		if f.held || f.moved || f.target == nil || t.Now().Sub(f.start) < LongPressDuration {
+			continue
+		}
+		f.held = true
+
+		gesture, ok := f.target.(events.GestureEvent)
+		if !ok {
+			continue
+		}
+		handled, err := gesture.RunLongPress()
+		if handled {
+			f.done = true
+			t.press(f, false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Makes the fingers on the event forget it, typically when it is unregistered. The event is released
+func (t *Tracker) Forget(e events.ClickEvent) {
+	for _, f := range t.fingers {
+		if f.target == e {
+			markPressed(f.target, false)
+			f.target = nil
+		}
+	}
+}
+
+// Forgets every finger, releasing the events they pressed
+func (t *Tracker) Release() {
+	for id, f := range t.fingers {
+		markPressed(f.target, false)
+		delete(t.fingers, id)
+	}
+}
+
+// Returns the direction the finger was swiped in, if it moved far and fast enough for a swipe
+func (f *finger) swipe(x, y int32, now time.Time) (int, bool) {
+	dx, dy := x-f.x, y-f.y
+	if now.Sub(f.start) > SwipeDuration || (abs(dx) < SwipeDistance && abs(dy) < SwipeDistance) {
+		return 0, false
+	}
+	switch {
+	case abs(dy) >= abs(dx) && dy < 0:
+		return events.SwipeUp, true
+	case abs(dy) >= abs(dx):
+		return events.SwipeDown, true
+	case dx < 0:
+		return events.SwipeLeft, true
+	default:
+		return events.SwipeRight, true
+	}
+}
+
+// Marks whether the finger presses its target. Several fingers may press the same event, which is only
+// released once none of them presses it anymore
+func (t *Tracker) press(f *finger, pressing bool) {
+	f.pressing = pressing
+	if !pressing {
+		for _, other := range t.fingers {
+			if other != f && other.target == f.target && other.pressing {
+				return
+			}
+		}
+	}
+	markPressed(f.target, pressing)
+}
+
+// Marks the event as pressed or released if it is drawn accordingly. The event may be nil
+func markPressed(e events.ClickEvent, pressed bool) {
+	if state, ok := e.(events.PointerStateEvent); ok {
+		state.SetPressed(pressed)
+	}
+}
+
+func abs(n int32) int32 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
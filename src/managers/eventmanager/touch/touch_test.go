@@ -0,0 +1,174 @@
+package touch
+
+import (
+	"CardGameGo/src/managers/eventmanager/events"
+	"testing"
+	"time"
+)
+
+// A button at (0, 0), 400 pixels wide and high, counting what it is asked to do
+type button struct {
+	taps, longPresses int
+	swipes            []int
+	pressed           bool
+
+	// Whether the button reacts to the gestures, rather than having them handled like a tap
+	gestures bool
+}
+
+func (b *button) GetX() int32      { return 0 }
+func (b *button) GetY() int32      { return 0 }
+func (b *button) GetWidth() int32  { return 400 }
+func (b *button) GetHeight() int32 { return 400 }
+
+func (b *button) RunCallback(...interface{}) error {
+	b.taps++
+	return nil
+}
+
+func (b *button) RunLongPress() (bool, error) {
+	b.longPresses++
+	return b.gestures, nil
+}
+
+func (b *button) RunSwipe(direction int) (bool, error) {
+	b.swipes = append(b.swipes, direction)
+	return b.gestures, nil
+}
+
+func (b *button) SetHovered(bool) {}
+
+func (b *button) SetPressed(pressed bool) {
+	b.pressed = pressed
+}
+
+// Returns a tracker of the fingers on the button, whose clock only moves forward when told to
+func newTestTracker(b *button) (*Tracker, func(time.Duration)) {
+	t := NewTracker(func(x, y int32) events.ClickEvent {
+		if x >= 0 && x <= b.GetWidth() && y >= 0 && y <= b.GetHeight() {
+			return b
+		}
+		return nil
+	})
+	now := time.Unix(0, 0)
+	t.Now = func() time.Time { return now }
+	return t, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestTheButtonStaysPressedWhileAFingerHoldsIt(t *testing.T) {
+	b := &button{}
+	tracker, _ := newTestTracker(b)
+
+	tracker.Down(1, 10, 10)
+	tracker.Down(2, 50, 50)
+	if err := tracker.Up(1, 10, 10); err != nil {
+		t.Fatal(err)
+	}
+	if !b.pressed {
+		t.Fatal("the button was released while the second finger still holds it")
+	}
+
+	tracker.Move(2, 500, 500)
+	if b.pressed {
+		t.Fatal("the button is still pressed once the second finger slid off it")
+	}
+	tracker.Move(2, 60, 60)
+	if !b.pressed {
+		t.Fatal("the button is not pressed again once the second finger slid back on it")
+	}
+
+	if err := tracker.Up(2, 60, 60); err != nil {
+		t.Fatal(err)
+	}
+	if b.pressed {
+		t.Fatal("the button is still pressed once every finger left it")
+	}
+	if b.taps != 2 {
+		t.Fatalf("the button was tapped %d times, expected 2", b.taps)
+	}
+}
+
+func TestLongPresses(t *testing.T) {
+	tests := []struct {
+		name     string
+		gestures bool
+		held     time.Duration
+		moveTo   int32
+		long     int
+		taps     int
+	}{
+		{"a long press is not a tap", true, LongPressDuration, 10, 1, 0},
+		{"a short press taps", true, LongPressDuration - time.Millisecond, 10, 0, 1},
+		{"a move within the slop still long presses", true, LongPressDuration, 10 + TouchSlop, 1, 0},
+		{"a move beyond the slop does not long press", true, LongPressDuration, 11 + TouchSlop, 0, 1},
+		{"an ignored long press taps", false, LongPressDuration, 10, 1, 1},
+	}
+	for _, test := range tests {
+		b := &button{gestures: test.gestures}
+		tracker, wait := newTestTracker(b)
+
+		tracker.Down(1, 10, 10)
+		tracker.Move(1, test.moveTo, 10)
+		wait(test.held)
+		if err := tracker.RunLongPresses(); err != nil {
+			t.Fatal(err)
+		}
+		if err := tracker.RunLongPresses(); err != nil {
+			t.Fatal(err)
+		}
+		if err := tracker.Up(1, test.moveTo, 10); err != nil {
+			t.Fatal(err)
+		}
+
+		if b.longPresses != test.long || b.taps != test.taps {
+			t.Errorf("%s: %d long presses and %d taps, expected %d and %d", test.name, b.longPresses, b.taps,
+				test.long, test.taps)
+		}
+		if b.pressed {
+			t.Errorf("%s: the button is still pressed", test.name)
+		}
+	}
+}
+
+func TestSwipes(t *testing.T) {
+	tests := []struct {
+		name      string
+		gestures  bool
+		dx, dy    int32
+		duration  time.Duration
+		direction int
+		swiped    bool
+		taps      int
+	}{
+		{"a swipe up", true, 0, -SwipeDistance, SwipeDuration, events.SwipeUp, true, 0},
+		{"a swipe down", true, 10, SwipeDistance, 0, events.SwipeDown, true, 0},
+		{"a swipe left", true, -SwipeDistance, 20, 0, events.SwipeLeft, true, 0},
+		{"a swipe right", true, SwipeDistance, -20, 0, events.SwipeRight, true, 0},
+		{"a short move taps", true, 0, 1 - SwipeDistance, 0, 0, false, 1},
+		{"a slow move taps", true, 0, -SwipeDistance, SwipeDuration + time.Millisecond, 0, false, 1},
+		{"an ignored swipe taps", false, 0, -SwipeDistance, 0, events.SwipeUp, true, 1},
+	}
+	for _, test := range tests {
+		b := &button{gestures: test.gestures}
+		tracker, wait := newTestTracker(b)
+
+		// Starting from the middle of the button, every move ends over it
+		tracker.Down(1, 200, 200)
+		x, y := 200+test.dx, 200+test.dy
+		tracker.Move(1, x, y)
+		wait(test.duration)
+		if err := tracker.Up(1, x, y); err != nil {
+			t.Fatal(err)
+		}
+
+		switch {
+		case test.swiped && (len(b.swipes) != 1 || b.swipes[0] != test.direction):
+			t.Errorf("%s: swiped %v, expected %d", test.name, b.swipes, test.direction)
+		case !test.swiped && len(b.swipes) != 0:
+			t.Errorf("%s: swiped %v, expected no swipe", test.name, b.swipes)
+		}
+		if b.taps != test.taps {
+			t.Errorf("%s: tapped %d times, expected %d", test.name, b.taps, test.taps)
+		}
+	}
+}
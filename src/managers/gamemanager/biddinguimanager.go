@@ -0,0 +1,184 @@
+package gamemanager
+
+import (
+	"CardGameGo/src/components/buttons/rectbutton"
+	"CardGameGo/src/game"
+	"CardGameGo/src/managers/eventmanager"
+	"CardGameGo/src/managers/fontmanager"
+	"CardGameGo/src/utils"
+	"CardGameGo/src/variants"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// The largest bid a button is created for. Variants never deal more than 13 cards per seat.
+const maxBid = 13
+
+// The number of bid buttons drawn per row
+const bidColumns = 5
+
+var bidButtons = make(map[int]*rectbutton.RectangularButton)
+var confirmBidButton *rectbutton.RectangularButton = nil
+var biddingStatusText *rectbutton.RectangularButton = nil
+
+// The ui of the bidding phase. It draws the same table as the GameUiManager it was created from (player
+// icons, the device player's hand and the HUD) but replaces the play and claim buttons with a grid of bid
+// buttons. Only the bids that the variant allows are selectable.
+type BiddingUiManager struct {
+	// The ui manager of the game being bid on
+	GameUi *GameUiManager
+
+	selectedBid int
+	bidSelected bool
+}
+
+// Provided constructor
+func NewBidding(gameUi *GameUiManager) *BiddingUiManager {
+	return &BiddingUiManager{
+		GameUi: gameUi,
+	}
+}
+
+func bidCallBackGenerator(bui *BiddingUiManager, bid int) func(...interface{}) error {
+	return func(...interface{}) error {
+		if !bui.isLegal(bid) {
+			return nil
+		}
+		if bui.bidSelected && bui.selectedBid == bid {
+			bui.bidSelected = false
+		} else {
+			bui.selectedBid, bui.bidSelected = bid, true
+		}
+		return nil
+	}
+}
+
+func (bui *BiddingUiManager) Init(eventManager *eventmanager.EventManager, fontManager *fontmanager.FontManager) {
+	font, _ := fontManager.GetFont("universalfruitcake", 20)
+
+	// Init bid buttons
+	for bid := 0; bid <= maxBid; bid++ {
+		bidButtons[bid] = rectbutton.New(variants.BidString(bid), 65, 65, utils.GRAY, font)
+		bidButtons[bid].CallBack = bidCallBackGenerator(bui, bid)
+	}
+
+	// Init confirm button
+	confirmBidButton = rectbutton.New("Bid", 200, 70, utils.GREEN, font)
+	confirmBidButton.CallBack = func(i ...interface{}) error {
+		if !bui.bidSelected {
+			return nil
+		}
+		err := bui.GameUi.bid(bui.selectedBid)
+		if err != nil {
+			return err
+		}
+		bui.bidSelected = false
+		return nil
+	}
+
+	// Init status text
+	biddingStatusText = rectbutton.New("", 300, 50, &sdl.Color{R: 168, G: 235, B: 254, A: 255}, font)
+
+	bui.Register(eventManager)
+}
+
+// Registers the bid buttons with the event manager of the bidding screen. Refer to GameUiManager.Register
+func (bui *BiddingUiManager) Register(eventManager *eventmanager.EventManager) {
+	for bid := 0; bid <= maxBid; bid++ {
+		eventManager.RegisterEvent(bidButtons[bid])
+	}
+	eventManager.RegisterEvent(confirmBidButton)
+}
+
+// Reports whether the device player may make the bid right now
+func (bui *BiddingUiManager) isLegal(bid int) bool {
+	for _, legal := range bui.GameUi.Game.LegalBids(bui.GameUi.DevicePlayer.Direction) {
+		if legal == bid {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether the bidding phase is over and the game screen should be shown instead
+func (bui *BiddingUiManager) Done() bool {
+	return bui.GameUi.Game.Phase != game.Bidding
+}
+
+func (bui *BiddingUiManager) Draw(winWidth, winHeight int32, renderer *sdl.Renderer) error {
+	ui := bui.GameUi
+
+	_, _, err := ui.drawCardRack(winWidth, winHeight, renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawOpponentsAndPlayedCards(winWidth, winHeight, renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawClaimedHands(renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawTrump(renderer)
+	if err != nil {
+		return err
+	}
+
+	gridWidth := bidColumns*bidButtons[0].Width + (bidColumns-1)*10
+	gridX, gridY := (winWidth-gridWidth)/2, winHeight/2-120
+
+	err = bui.drawStatus(winWidth, gridY-biddingStatusText.Height-10, renderer)
+	if err != nil {
+		return err
+	}
+
+	// Draw the bid buttons. Bids above the hand size of the variant are hidden by being drawn off the screen
+	handSize := ui.Game.Variant.HandSize()
+	for bid := 0; bid <= maxBid; bid++ {
+		button := bidButtons[bid]
+		if bid > handSize {
+			err = button.Draw(winWidth, winHeight, renderer)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch {
+		case bui.bidSelected && bui.selectedBid == bid:
+			button.Color = utils.GREEN
+		case bui.isLegal(bid):
+			button.Color = utils.GRAY
+		default:
+			button.Color = utils.SILVER
+		}
+
+		row, column := int32(bid/bidColumns), int32(bid%bidColumns)
+		err = button.Draw(gridX+column*(button.Width+10), gridY+row*(button.Height+10), renderer)
+		if err != nil {
+			return err
+		}
+	}
+
+	rows := int32(handSize/bidColumns + 1)
+	if bui.bidSelected {
+		confirmBidButton.Color = utils.GREEN
+	} else {
+		confirmBidButton.Color = utils.SILVER
+	}
+	return confirmBidButton.Draw((winWidth-confirmBidButton.Width)/2,
+		gridY+rows*(bidButtons[0].Height+10)+15, renderer)
+}
+
+func (bui *BiddingUiManager) drawStatus(winWidth, y int32, renderer *sdl.Renderer) error {
+	current := bui.GameUi.Game.Current()
+	if current == bui.GameUi.DevicePlayer.Direction {
+		biddingStatusText.BtnText = "Your bid"
+	} else {
+		biddingStatusText.BtnText = "Waiting for " + utils.DirectionToString(current)
+	}
+	return biddingStatusText.Draw((winWidth-biddingStatusText.Width)/2, y, renderer)
+}
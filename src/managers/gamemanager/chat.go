@@ -0,0 +1,305 @@
+package gamemanager
+
+import (
+	"CardGameGo/src/components/buttons/rectbutton"
+	"CardGameGo/src/components/textinput"
+	"CardGameGo/src/managers/eventmanager"
+	"CardGameGo/src/managers/fontmanager"
+	"CardGameGo/src/managers/interfaces"
+	"CardGameGo/src/protocol"
+	"CardGameGo/src/utils"
+	"errors"
+	"github.com/veandco/go-sdl2/sdl"
+	"strings"
+	"time"
+)
+
+// The number of chat lines kept, the older ones are dropped
+const chatLogSize = 50
+
+// The number of chat lines listed on the chat panel
+const chatPanelLines = 8
+
+// The number of recent chat lines shown on top of the table while the chat panel is hidden, and for how long
+const chatOverlayLines = 3
+const chatOverlayDuration = 8 * time.Second
+
+// How long a reaction is shown next to the seat of the player who sent it
+const reactionDuration = 2 * time.Second
+
+// The number of reaction buttons per row of the chat panel
+const reactionsPerRow = 4
+
+var chatButton *rectbutton.RectangularButton = nil
+var chatInput *textinput.TextInput = nil
+var sendChatButton *rectbutton.RectangularButton = nil
+var closeChatButton *rectbutton.RectangularButton = nil
+var reactionButtons []*rectbutton.RectangularButton = nil
+var reactionText *rectbutton.RectangularButton = nil
+
+// A line of chat along with the time it was received
+type ChatLine struct {
+	protocol.Chat
+	Time time.Time
+}
+
+// A reaction shown next to the seat of its player until the given time
+type shownReaction struct {
+	reaction string
+	until    time.Time
+}
+
+func reactionCallBackGenerator(ui *GameUiManager, reaction string) func(...interface{}) error {
+	return func(...interface{}) error {
+		if !ui.ShowChat {
+			return nil
+		}
+		ui.ShowChat = false
+		return ui.React(reaction)
+	}
+}
+
+func (ui *GameUiManager) initChat(fontManager *fontmanager.FontManager) {
+	font, _ := fontManager.GetFont("universalfruitcake", 20)
+
+	chatButton = rectbutton.New("Chat", 150, 50, utils.GREEN, font)
+	chatButton.CallBack = func(i ...interface{}) error {
+		if ui.overlayShown() {
+			return nil
+		}
+		ui.ShowChat = true
+		return nil
+	}
+
+	// The line typed is sent when return is pressed or the send button is tapped
+	chatInput = textinput.New("Say something", 500, 70, utils.SILVER, font)
+	chatInput.MaxLength = protocol.MaxChatLength
+	chatInput.OnSubmit = func(line string) error {
+		if strings.TrimSpace(line) == "" {
+			return nil
+		}
+		chatInput.Text = ""
+		return ui.Say(line)
+	}
+
+	sendChatButton = rectbutton.New("Send", 150, 70, utils.GREEN, font)
+	sendChatButton.CallBack = func(i ...interface{}) error {
+		if !ui.ShowChat {
+			return nil
+		}
+		return chatInput.Submit()
+	}
+
+	closeChatButton = rectbutton.New("Close", 200, 70, utils.GREEN, font)
+	closeChatButton.CallBack = func(i ...interface{}) error {
+		chatInput.Blur()
+		ui.ShowChat = false
+		return nil
+	}
+
+	reactionButtons = make([]*rectbutton.RectangularButton, len(protocol.Reactions))
+	for i, reaction := range protocol.Reactions {
+		reactionButtons[i] = rectbutton.New(reaction, 150, 60, utils.GREEN, font)
+		reactionButtons[i].CallBack = reactionCallBackGenerator(ui, reaction)
+	}
+
+	// Init the bubble drawn next to a player who just reacted
+	reactionText = rectbutton.New("", 150, 40, &sdl.Color{R: 255, G: 236, B: 140, A: 255}, font)
+}
+
+// Registers the buttons of the chat. Spectators may chat but not react, so the reaction buttons are left out
+// for them
+func (ui *GameUiManager) registerChat(eventManager *eventmanager.EventManager, reactions bool) {
+	eventManager.RegisterEvent(chatButton)
+	eventManager.RegisterEvent(chatInput)
+	eventManager.RegisterEvent(sendChatButton)
+	if reactions {
+		for _, button := range reactionButtons {
+			eventManager.RegisterEvent(button)
+		}
+	}
+	eventManager.RegisterEvent(closeChatButton)
+}
+
+// Reports whether the scoreboard or the chat is drawn on top of the table. While one of them is shown, the
+// buttons of the table ignore clicks
+func (ui *GameUiManager) overlayShown() bool {
+	return ui.ShowScoreboard || ui.ShowChat
+}
+
+// Sends a line of chat to every player. In a game played alone the line is only added to the chat log
+func (ui *GameUiManager) Say(line string) error {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return errors.New("chat error: empty message")
+	}
+	if ui.Client != nil {
+		return ui.Client.Say(line)
+	}
+	if ui.Server != nil {
+		return ui.Server.Say(line)
+	}
+	ui.receive(&protocol.Chat{Seat: ui.DevicePlayer.Direction, Name: ui.DevicePlayer.Name, Text: line})
+	return nil
+}
+
+// Shows a quick reaction, one of protocol.Reactions, next to the device player's seat on every device
+func (ui *GameUiManager) React(reaction string) error {
+	if ui.Spectating() {
+		return errors.New("reaction error: spectators cannot react")
+	}
+	if ui.Client != nil {
+		return ui.Client.React(reaction)
+	}
+	if ui.Server != nil {
+		return ui.Server.React(reaction)
+	}
+	ui.receive(&protocol.Reaction{Seat: ui.DevicePlayer.Direction, Reaction: reaction})
+	return nil
+}
+
+// Adds a line of chat to the chat log or shows a reaction. Other messages are ignored
+func (ui *GameUiManager) receive(message protocol.Message) {
+	ui.lastChange = time.Now()
+	switch m := message.(type) {
+	case *protocol.Chat:
+		ui.ChatLog = append(ui.ChatLog, ChatLine{Chat: *m, Time: time.Now()})
+		if len(ui.ChatLog) > chatLogSize {
+			ui.ChatLog = ui.ChatLog[len(ui.ChatLog)-chatLogSize:]
+		}
+	case *protocol.Reaction:
+		ui.reactions[m.Seat] = shownReaction{reaction: m.Reaction, until: time.Now().Add(reactionDuration)}
+	}
+}
+
+// Returns the line as drawn, for example "Alice: Hello". The players without a name are named after their
+// seat
+func (ui *GameUiManager) chatLineString(line ChatLine) string {
+	name := line.Name
+	if name == "" && line.Seat == protocol.Spectator {
+		name = "Spectator"
+	} else if name == "" {
+		name = utils.DirectionToString(line.Seat)
+	}
+	return name + ": " + line.Text
+}
+
+// Draws the chat button under the score button along with the chat panel when it is shown. Otherwise the
+// most recent lines are drawn on top of the table for a moment
+func (ui *GameUiManager) drawChat(w, h int32, renderer *sdl.Renderer) error {
+	err := chatButton.Draw(50, 50+claimedHandsText.Height+trumpText.Height+scoreButton.Height, renderer)
+	if err != nil {
+		return err
+	}
+
+	if !ui.ShowChat {
+		err = ui.drawChatOverlay(w, renderer)
+		if err != nil {
+			return err
+		}
+		return ui.hideChat(w, h, renderer)
+	}
+
+	panel := sdl.Rect{X: 40, Y: 200, W: w - 80, H: h - 450}
+	_ = renderer.SetDrawColor(utils.GRAY.R, utils.GRAY.G, utils.GRAY.B, 255)
+	err = renderer.FillRect(&panel)
+	if err != nil {
+		return err
+	}
+
+	// The most recent lines, the last one at the bottom
+	y := panel.Y + 20
+	first := len(ui.ChatLog) - chatPanelLines
+	if first < 0 {
+		first = 0
+	}
+	for _, line := range ui.ChatLog[first:] {
+		err = drawLabel(ui.chatLineString(line), panel.X+20, y, renderer)
+		if err != nil {
+			return err
+		}
+		y += scoreboardLine
+	}
+	y = panel.Y + 20 + chatPanelLines*scoreboardLine + 20
+
+	if chatInput.Focused() {
+		chatInput.Color = utils.SILVER
+	} else {
+		chatInput.Color = utils.GREEN
+	}
+	chatInput.Width = panel.W - sendChatButton.Width - 60
+	err = chatInput.Draw(panel.X+20, y, renderer)
+	if err != nil {
+		return err
+	}
+	err = sendChatButton.Draw(panel.X+panel.W-sendChatButton.Width-20, y, renderer)
+	if err != nil {
+		return err
+	}
+	y += chatInput.Height + 20
+
+	// The reactions are only offered to the players sitting at the table
+	for i, button := range reactionButtons {
+		if ui.Spectating() {
+			err = button.Draw(w, h, renderer)
+		} else {
+			x := panel.X + 20 + int32(i%reactionsPerRow)*(button.Width+15)
+			err = button.Draw(x, y+int32(i/reactionsPerRow)*(button.Height+15), renderer)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return closeChatButton.Draw((w-closeChatButton.Width)/2, panel.Y+panel.H-closeChatButton.Height-20, renderer)
+}
+
+// Draws the buttons of the chat panel off the screen so that they cannot be clicked while it is hidden
+func (ui *GameUiManager) hideChat(w, h int32, renderer *sdl.Renderer) error {
+	err := chatInput.Draw(w, h, renderer)
+	if err != nil {
+		return err
+	}
+	err = sendChatButton.Draw(w, h, renderer)
+	if err != nil {
+		return err
+	}
+	for _, button := range reactionButtons {
+		err = button.Draw(w, h, renderer)
+		if err != nil {
+			return err
+		}
+	}
+	return closeChatButton.Draw(w, h, renderer)
+}
+
+// Draws the chat lines received recently at the top of the table
+func (ui *GameUiManager) drawChatOverlay(w int32, renderer *sdl.Renderer) error {
+	var recent []ChatLine
+	for i := len(ui.ChatLog) - 1; i >= 0 && len(recent) < chatOverlayLines; i-- {
+		if time.Since(ui.ChatLog[i].Time) > chatOverlayDuration {
+			break
+		}
+		recent = append([]ChatLine{ui.ChatLog[i]}, recent...)
+	}
+
+	y := int32(110)
+	for _, line := range recent {
+		err := drawLabel(ui.chatLineString(line), w/2-250, y, renderer)
+		if err != nil {
+			return err
+		}
+		y += scoreboardLine
+	}
+	return nil
+}
+
+// Draws the reaction the player just sent, if any, in a bubble with its top left corner at the given position
+func (ui *GameUiManager) drawReaction(player *interfaces.Player, x, y int32, renderer *sdl.Renderer) error {
+	shown, ok := ui.reactions[player.Direction]
+	if !ok || time.Now().After(shown.until) {
+		return nil
+	}
+	reactionText.BtnText = shown.reaction
+	return reactionText.Draw(x, y, renderer)
+}
@@ -1,25 +1,47 @@
 package gamemanager
 
 import (
+	"CardGameGo/src/bots"
+	"CardGameGo/src/cards"
 	"CardGameGo/src/components/buttons/imagebutton"
 	"CardGameGo/src/components/buttons/rectbutton"
+	"CardGameGo/src/game"
 	"CardGameGo/src/managers/eventmanager"
+	"CardGameGo/src/managers/eventmanager/events"
 	"CardGameGo/src/managers/fontmanager"
 	"CardGameGo/src/managers/imgmanager"
 	"CardGameGo/src/managers/interfaces"
+	"CardGameGo/src/network"
+	"CardGameGo/src/protocol"
+	"CardGameGo/src/record"
+	"CardGameGo/src/scoring"
 	"CardGameGo/src/utils"
+	"CardGameGo/src/variants"
 	"errors"
 	"fmt"
 	"github.com/veandco/go-sdl2/sdl"
 	"strconv"
+	"time"
 )
 
-var allCards = make(map[string]*imagebutton.ImageButton)
+// The default time a bot waits before taking its turn, so that the device player can follow the game
+const DefaultBotDelay = 800 * time.Millisecond
+
+// How long the details of a card are shown once it was long pressed
+const cardDetailsDuration = 3 * time.Second
+
+var allCards = make(map[cards.Card]*imagebutton.ImageButton)
 var playButton *rectbutton.RectangularButton = nil
 var claimButton *rectbutton.RectangularButton = nil
 var playerIcon *rectbutton.RectangularButton = nil
 var claimedHandsText *rectbutton.RectangularButton = nil
+var trumpText *rectbutton.RectangularButton = nil
+var bidText *rectbutton.RectangularButton = nil
 var newGameButton *rectbutton.RectangularButton = nil
+var nextHandButton *rectbutton.RectangularButton = nil
+var scoreButton *rectbutton.RectangularButton = nil
+var connectionText *rectbutton.RectangularButton = nil
+var cardDetailsText *rectbutton.RectangularButton = nil
 
 var cardYPosition int32
 
@@ -31,73 +53,159 @@ type GameUiManager struct {
 	DevicePlayer  *interfaces.Player
 	CurrentPlayer *interfaces.Player
 
-	Cards       []string
-	PlayedCards []string
+	// The match being played and its current hand. The ui manager never modifies the hands, the bids or
+	// the trick itself, it only forwards the device player's actions to the game and draws whatever the
+	// game contains. Game always points to Match.Hand. Refer to src/game for more info
+	Match *game.Match
+	Game  *game.Game
+
+	// Reports whether the scoreboard is shown on top of the table. While it is shown, the buttons of the
+	// table ignore clicks. The scoreboard is not drawn by Draw but by the overlay showing it, refer to
+	// DrawScoreboard
+	ShowScoreboard bool
+
+	// Reports whether the chat panel is drawn on top of the table, refer to ShowScoreboard
+	ShowChat bool
+
+	// The most recent lines of chat, the last one being the most recent. Refer to Say
+	ChatLog []ChatLine
+
+	// The computer players of the seats that are not taken by a person, by direction. Refer to src/bots for
+	// more info
+	Bots map[int]bots.Bot
+
+	// The time a bot waits before taking its turn
+	BotDelay time.Duration
+
+	// The server of a game hosted by this device, or nil. The bots never play the seats taken by its clients
+	Server *network.Server
+
+	// Reports whether the bots play the seats of the clients that lost their connection until they come
+	// back. Otherwise the game waits for them
+	BotsTakeOver bool
+
+	// The connection to the host of a game joined by this device, or nil. When set, Match is only a copy of
+	// the host's match as seen from the device player's seat and every action is sent to the host instead.
+	// Refer to src/network for more info
+	Client *network.Client
+
 	DeviceTurn  bool
 	GameStarted bool
 
-	selectedCard string
-	claimedHands int
+	// The file the game is written to after every change so that it can be resumed, or empty to never save
+	// it. Refer to Save
+	SavePath string
+
+	// The directory every finished hand is recorded to, or empty to never record them. Refer to src/record
+	RecordDir string
+
+	selectedCard cards.Card
+
+	// The reaction of every seat that reacted recently. Refer to React
+	reactions map[int]shownReaction
+
+	// The players of this device's own game, kept aside while playing a game joined on another device
+	local       *interfaces.GameContext
+	localDevice *interfaces.Player
+
+	// The last time the game changed, used to delay the bots' turns
+	lastAction time.Time
+
+	// The last time anything drawn on the table changed, refer to LastChange
+	lastChange time.Time
+
+	// The card long pressed, whose details are shown until detailsUntil
+	detailedCard cards.Card
+	detailsUntil time.Time
+
+	// The last hand recorded, so that every hand is only recorded once
+	recorded *game.Game
 }
 
-func callBackGenerator(ui *GameUiManager, cardName string) func(...interface{}) error {
+func callBackGenerator(ui *GameUiManager, card cards.Card) func(...interface{}) error {
 	return func(...interface{}) error {
-		if ui.selectedCard == cardName {
-			ui.selectedCard = ""
+		if ui.overlayShown() {
+			return nil
+		}
+		if ui.selectedCard == card {
+			ui.selectedCard = cards.Card{}
 		} else {
-			ui.selectedCard = cardName
+			ui.selectedCard = card
 		}
 		return nil
 	}
 }
 
+// Shows the details of the card long pressed, refer to cardDetails
+func longPressCallBackGenerator(ui *GameUiManager, card cards.Card) func(...interface{}) error {
+	return func(...interface{}) error {
+		if ui.overlayShown() {
+			return nil
+		}
+		ui.detailedCard = card
+		ui.detailsUntil = time.Now().Add(cardDetailsDuration)
+		return nil
+	}
+}
+
+// Plays the card swiped up, without selecting it first, when it is the device player's turn
+func swipeUpCallBackGenerator(ui *GameUiManager, card cards.Card) func(...interface{}) error {
+	return func(...interface{}) error {
+		if ui.overlayShown() || ui.CurrentPlayer != ui.DevicePlayer || ui.Game.Phase != game.Playing {
+			return nil
+		}
+		err := ui.play(card)
+		if err != nil {
+			return err
+		}
+		ui.selectedCard = cards.Card{}
+		return nil
+	}
+}
+
 func (ui *GameUiManager) Init(manager *imgmanager.ImageManager,
 	eventManager *eventmanager.EventManager, fontManager *fontmanager.FontManager, renderer *sdl.Renderer) {
 
 	// Init card image buttons
-	cardNames := []string{"c1", "c2", "c3", "c4", "c5", "c6", "c7", "c8", "c9", "cX", "cJ", "cQ", "cK",
-		"s1", "s2", "s3", "s4", "s5", "s6", "s7", "s8", "s9", "sX", "sJ", "sQ", "sK",
-		"h1", "h2", "h3", "h4", "h5", "h6", "h7", "h8", "h9", "hX", "hJ", "hQ", "hK",
-		"d1", "d2", "d3", "d4", "d5", "d6", "d7", "d8", "d9", "dX", "dJ", "dQ", "dK"}
+	deckCards := cards.All()
 
-	for _, card := range cardNames {
+	for _, card := range deckCards {
 		allCards[card] = imagebutton.New(GetCard(card, manager))
 	}
 
+	// A card is selected by a tap. On a touch screen it may also be long pressed for its details, or swiped
+	// up to play it right away
 	for key, card := range allCards {
 		card.CallBack = callBackGenerator(ui, key)
-	}
-
-	//for i := len(cardNames) - 1; i >= 0; i-- {
-	//	eventManager.RegisterEvent(allCards[cardNames[i]])
-	//}
-	for _, card := range cardNames {
-		eventManager.RegisterEvent(allCards[card])
+		card.LongPressCallBack = longPressCallBackGenerator(ui, key)
+		card.SwipeCallBacks = map[int]func(...interface{}) error{
+			events.SwipeUp: swipeUpCallBackGenerator(ui, key),
+		}
 	}
 
 	// Init play card button
 	font, _ := fontManager.GetFont("universalfruitcake", 20)
 	playButton = rectbutton.New("Play", 200, 100, utils.GREEN, font)
 	playButton.CallBack = func(inter ...interface{}) error {
-		if ui.selectedCard == "" {
+		if ui.overlayShown() || ui.selectedCard.IsZero() {
 			return nil
 		}
-		ui.PlayedCards[ui.DevicePlayer.Direction] = ui.selectedCard
-		ui.removeSelectedCard()
+		err := ui.play(ui.selectedCard)
+		if err != nil {
+			return err
+		}
+		ui.selectedCard = cards.Card{}
 		return nil
 	}
-	eventManager.RegisterEvent(playButton)
 
 	// Init claim button
 	claimButton = rectbutton.New("Claim", 200, 100, utils.GREEN, font)
 	claimButton.CallBack = func(i ...interface{}) error {
-		ui.claimedHands++
-		for i := range ui.PlayedCards {
-			ui.PlayedCards[i] = ""
+		if ui.overlayShown() {
+			return nil
 		}
-		return nil
+		return ui.claim()
 	}
-	eventManager.RegisterEvent(claimButton)
 
 	// Init Player Icons
 	playerIcon = rectbutton.New("", 150, 150, utils.SILVER, font)
@@ -106,42 +214,504 @@ func (ui *GameUiManager) Init(manager *imgmanager.ImageManager,
 	}
 
 	// Init claimed hands text
-	claimedHandsText = rectbutton.New("Claimed: " + strconv.Itoa(ui.claimedHands), 150, 50, &sdl.Color{168, 235, 254, 255}, font)
+	claimedHandsText = rectbutton.New("Claimed: 0", 150, 50, &sdl.Color{R: 168, G: 235, B: 254, A: 255}, font)
+
+	// Init the bid text drawn under every player icon
+	bidText = rectbutton.New("", 150, 40, &sdl.Color{R: 168, G: 235, B: 254, A: 255}, font)
+
+	// Init trump text
+	trumpText = rectbutton.New("Trump: None", 150, 50, &sdl.Color{R: 168, G: 235, B: 254, A: 255}, font)
 
 	// Init New Game Button
 	newGameButton = rectbutton.New("New Game", 150, 50, utils.GREEN, font)
 	newGameButton.CallBack = func(i ...interface{}) error {
-		ui.NewGame()
+		if ui.overlayShown() || ui.Client != nil {
+			return nil
+		}
+		return ui.NewGame()
+	}
+
+	// Init Next Hand Button
+	nextHandButton = rectbutton.New("Next Hand", 200, 100, utils.GREEN, font)
+	nextHandButton.CallBack = func(i ...interface{}) error {
+		if ui.overlayShown() || ui.Client != nil || ui.Game.Phase != game.HandOver {
+			return nil
+		}
+		return ui.NextHand()
+	}
+
+	// Init the text shown while the connection to the host is lost
+	connectionText = rectbutton.New("Reconnecting...", 250, 50, utils.SILVER, font)
+
+	// Init the details of the card long pressed
+	cardDetailsText = rectbutton.New("", 500, 50, utils.SILVER, font)
+
+	// Init Score Button
+	scoreButton = rectbutton.New("Score", 150, 50, utils.GREEN, font)
+	scoreButton.CallBack = func(i ...interface{}) error {
+		if ui.overlayShown() {
+			return nil
+		}
+		ui.ShowScoreboard = true
 		return nil
 	}
+
+	ui.initChat(fontManager)
+	ui.initScoreboard(fontManager)
+	ui.Register(eventManager)
+}
+
+// Registers the cards and the buttons of the table with the event manager of the game screen. Init
+// registers them already, they must only be registered again after the event manager was cleared.
+func (ui *GameUiManager) Register(eventManager *eventmanager.EventManager) {
+	deckCards := cards.All()
+	//for i := len(deckCards) - 1; i >= 0; i-- {
+	//	eventManager.RegisterEvent(allCards[deckCards[i]])
+	//}
+	for _, card := range deckCards {
+		eventManager.RegisterEvent(allCards[card])
+	}
+	eventManager.RegisterEvent(playButton)
+	eventManager.RegisterEvent(claimButton)
 	eventManager.RegisterEvent(newGameButton)
+	eventManager.RegisterEvent(nextHandButton)
+	eventManager.RegisterEvent(scoreButton)
+	ui.registerChat(eventManager, true)
 }
 
 func New(devicePlayer *interfaces.Player, context interfaces.GameContext) *GameUiManager {
+	variant := context.Variant
+	if variant == nil {
+		variant = variants.All[0]
+	}
+
+	match := game.NewMatch(variant, context.Host.Direction, context.Seed)
 	ui := GameUiManager{
 		GameId:        context.GameId,
 		Players:       context.Players,
 		Host:          context.Host,
 		DevicePlayer:  devicePlayer,
 		CurrentPlayer: nil,
-		Cards:         nil,
-		PlayedCards:   []string{"", "", "", ""},
+		Match:         match,
+		Game:          match.Hand,
+		Bots:          make(map[int]bots.Bot),
+		BotDelay:      DefaultBotDelay,
+		BotsTakeOver:  true,
 		DeviceTurn:    false,
 		GameStarted:   false,
-		selectedCard:  "",
+		selectedCard:  cards.Card{},
+		reactions:     make(map[int]shownReaction),
 	}
 
 	return &ui
 }
 
-func (ui *GameUiManager) NewGame() {
-	cards := []string{"c1", "c2", "c3", "c4", "c5", "c6", "c7", "c8", "c9", "cX", "cJ", "cQ", "cK"}
-	ui.AssignCards(cards)
-	for i := range ui.PlayedCards {
-		ui.PlayedCards[i] = ""
+// Provided constructor for a device that joined a game hosted on another device. The match, the names of
+// the players and the host are filled in by Update once the host has sent the state of its game.
+func NewRemote(client *network.Client, name string) *GameUiManager {
+	devicePlayer := &interfaces.Player{Name: name, Direction: client.Seat}
+	players := map[*interfaces.Player]bool{devicePlayer: true}
+
+	ui := New(devicePlayer, interfaces.GameContext{Players: players, Host: devicePlayer})
+	ui.JoinGame(client, name)
+	return ui
+}
+
+// Leaves the current game and joins a game hosted on another device instead. The players and the match are
+// filled in by Update once the host has sent the state of its game. Refer to LeaveGame to get back to the
+// game of this device.
+func (ui *GameUiManager) JoinGame(client *network.Client, name string) {
+	_ = ui.StopHosting()
+	if ui.Client != nil {
+		_ = ui.Client.Close()
+	} else {
+		ui.local = &interfaces.GameContext{
+			GameId:  ui.GameId,
+			Players: ui.Players,
+			Host:    ui.Host,
+		}
+		ui.localDevice = ui.DevicePlayer
+	}
+
+	ui.Players = make(map[*interfaces.Player]bool)
+	for _, direction := range utils.DirectionOrder {
+		player := &interfaces.Player{Direction: direction}
+		if direction == client.Seat {
+			player.Name = name
+			player.Id = client.Id
+			ui.DevicePlayer = player
+		}
+		ui.Players[player] = true
+	}
+
+	// A spectator sees the table from the seat of the host, known once the host sent the state of its game
+	if client.Spectator {
+		ui.DevicePlayer = ui.PlayerAt(utils.North)
+	}
+	ui.GameId = client.GameId
+	ui.Host = nil
+	ui.CurrentPlayer = nil
+	ui.Client = client
+	ui.Match = game.NewMatch(ui.Match.Variant, client.Seat, 0)
+	ui.Game = ui.Match.Hand
+	ui.GameStarted = false
+	ui.ShowScoreboard = false
+	ui.ShowChat = false
+	ui.ChatLog = nil
+	ui.selectedCard = cards.Card{}
+}
+
+// Reports whether the device watches a game hosted on another device without playing it
+func (ui *GameUiManager) Spectating() bool {
+	return ui.Client != nil && ui.Client.Spectator
+}
+
+// Lets the spectators of the game hosted by this device see every hand, for example to coach the players
+func (ui *GameUiManager) SetOpenHands(open bool) {
+	if ui.Server == nil {
+		return
+	}
+	ui.Server.OpenHands = open
+	ui.Server.Broadcast()
+}
+
+// Reports whether the spectators of the game hosted by this device see every hand
+func (ui *GameUiManager) OpenHands() bool {
+	return ui.Server != nil && ui.Server.OpenHands
+}
+
+// Leaves the game joined on another device and gets back to the players of this device. The next call to
+// NewGame starts a game of this device again.
+func (ui *GameUiManager) LeaveGame() error {
+	if ui.Client == nil {
+		return nil
+	}
+	err := ui.Client.Close()
+	ui.Client = nil
+
+	if ui.local != nil {
+		ui.GameId = ui.local.GameId
+		ui.Players = ui.local.Players
+		ui.Host = ui.local.Host
+		ui.DevicePlayer = ui.localDevice
+	}
+	ui.CurrentPlayer = nil
+	ui.Match = game.NewMatch(ui.Match.Variant, ui.DevicePlayer.Direction, 0)
+	ui.Game = ui.Match.Hand
+	ui.GameStarted = false
+	ui.ShowScoreboard = false
+	ui.ShowChat = false
+	ui.ChatLog = nil
+	ui.selectedCard = cards.Card{}
+	return err
+}
+
+// Starts a new match of the same variant with the same first dealer and deals its first hand from a deck
+// shuffled with a new seed
+func (ui *GameUiManager) NewGame() error {
+	ui.Match = game.NewMatch(ui.Match.Variant, ui.Match.Hand.Dealer, cards.NewSeed())
+	ui.ShowScoreboard = false
+	if ui.Server != nil {
+		ui.Server.SetMatch(ui.Match)
+	}
+	return ui.Deal()
+}
+
+// Deals the first hand of the current match to every player. Refer to game.Match.Start for more info
+func (ui *GameUiManager) Deal() error {
+	err := ui.Match.Start()
+	if err != nil {
+		return err
+	}
+	ui.Game = ui.Match.Hand
+	ui.selectedCard = cards.Card{}
+	return ui.afterAction()
+}
+
+// Scores the hand that was just played and deals the next hand of the match
+func (ui *GameUiManager) NextHand() error {
+	err := ui.Match.NextHand()
+	if err != nil {
+		return err
+	}
+	ui.Game = ui.Match.Hand
+	ui.selectedCard = cards.Card{}
+	return ui.afterAction()
+}
+
+// Changes the game being played, abandoning the current match. The first hand of the new match is dealt by
+// the next call to Deal or NewGame.
+func (ui *GameUiManager) SetVariant(variant variants.Variant) {
+	ui.Match = game.NewMatch(variant, ui.Match.Hand.Dealer, 0)
+	ui.Game = ui.Match.Hand
+	ui.ShowScoreboard = false
+	if ui.Server != nil {
+		ui.Server.Match = ui.Match
+	}
+}
+
+// Hosts the game so that other devices can join it as clients. Refer to network.Server.Listen for the
+// address format
+func (ui *GameUiManager) HostGame(address string) error {
+	if ui.Client != nil {
+		return errors.New("host error: cannot host a game joined on another device")
+	}
+	if ui.Server != nil {
+		return nil
+	}
+
+	server := network.NewServer(ui.GameId, ui.Match, ui.DevicePlayer.Direction, ui.DevicePlayer.Name)
+	err := server.Listen(address)
+	if err != nil {
+		return err
+	}
+	ui.Server = server
+
+	// Failing to advertise the game is not fatal, the other devices can still join it from its address
+	err = server.Advertise()
+	if err != nil {
+		fmt.Printf("ignoring advertise error %q\n", err)
+	}
+	return nil
+}
+
+// Stops hosting the game, disconnecting every client
+func (ui *GameUiManager) StopHosting() error {
+	if ui.Server == nil {
+		return nil
+	}
+	err := ui.Server.Close()
+	ui.Server = nil
+	return err
+}
+
+// Overrides the trump suit chosen by the variant. Refer to game.Game.SetTrump for more info
+func (ui *GameUiManager) SetTrump(trump cards.Suit) error {
+	return ui.Game.SetTrump(trump)
+}
+
+// Returns the phase of the game being played
+func (ui *GameUiManager) Phase() game.Phase {
+	return ui.Game.Phase
+}
+
+// Lets a bot play the seat of the given direction. A nil bot leaves the seat to a person again
+func (ui *GameUiManager) SetBot(direction int, bot bots.Bot) {
+	if bot == nil {
+		delete(ui.Bots, direction)
+		return
+	}
+	ui.Bots[direction] = bot
+}
+
+// Applies the changes coming from the network and lets the bot of the seat whose turn it is take its turn
+// once BotDelay has passed since the last action. It is meant to be called once per frame.
+func (ui *GameUiManager) Update() error {
+	if ui.Client != nil {
+		return ui.updateClient()
+	}
+
+	if ui.Server != nil && ui.Server.Process() {
+		ui.applyLobby(ui.Server.Lobby(), ui.Server.HostSeat)
+		err := ui.afterAction()
+		if err != nil {
+			return err
+		}
+	}
+	if ui.Server != nil {
+		for _, message := range ui.Server.Messages() {
+			ui.receive(message)
+		}
+	}
+
+	if ui.ShowScoreboard {
+		return nil
+	}
+
+	seat := ui.Game.Current()
+	bot, ok := ui.Bots[seat]
+	if !ok || (ui.Server != nil && ui.Server.Seated(seat)) {
+		return nil
+	}
+	if ui.Server != nil && ui.Server.Away(seat) && !ui.BotsTakeOver {
+		return nil
+	}
+	if !bots.HasTurn(ui.Game, seat) || time.Since(ui.lastAction) < ui.BotDelay {
+		return nil
+	}
+
+	err := bots.Act(bot, ui.Game, seat)
+	if err != nil {
+		return err
+	}
+	return ui.afterAction()
+}
+
+// Replaces the match by the latest state received from the host
+func (ui *GameUiManager) updateClient() error {
+	// The client reconnects on its own, it only fails once it gave up getting its seat back
+	err := ui.Client.Err()
+	if err != nil {
+		fmt.Printf("ignoring connection error %q\n", err)
+		return ui.LeaveGame()
+	}
+
+	state, events := ui.Client.Poll()
+	if state != nil || len(events) > 0 {
+		ui.lastChange = time.Now()
 	}
-	ui.selectedCard = ""
-	ui.claimedHands = 0
+	for _, event := range events {
+		switch e := event.(type) {
+		case *protocol.Error:
+			fmt.Printf("ignoring action: %q\n", e.Message)
+		case *protocol.NewGame:
+			ui.ShowScoreboard = false
+		case *protocol.Lobby:
+			ui.applyLobby(e.Seats, e.Seat)
+			ui.GameStarted = e.Started
+		case *protocol.Chat, *protocol.Reaction:
+			ui.receive(e)
+		}
+	}
+	if state == nil {
+		return nil
+	}
+
+	match, err := state.Match()
+	if err != nil {
+		return err
+	}
+	ui.GameId = state.GameId
+	for player := range ui.Players {
+		player.Name = state.Players[player.Direction]
+		if player.Direction == state.HostSeat {
+			ui.Host = player
+		}
+	}
+	if ui.Spectating() {
+		ui.DevicePlayer = ui.Host
+	}
+
+	handOver := ui.Game.Phase != game.HandOver && match.Hand.Phase == game.HandOver
+	ui.Match = match
+	ui.Game = match.Hand
+	ui.sync()
+
+	// Show the result of every hand, like the host does
+	if handOver {
+		ui.ShowScoreboard = true
+	}
+	return nil
+}
+
+// Bids for the device player, or asks the host to when the game was joined on another device
+func (ui *GameUiManager) bid(bid int) error {
+	if ui.Client != nil {
+		return ui.Client.Bid(bid)
+	}
+	err := ui.Game.Bid(ui.DevicePlayer.Direction, bid)
+	if err != nil {
+		return err
+	}
+	return ui.afterAction()
+}
+
+// Plays a card for the device player, or asks the host to when the game was joined on another device
+func (ui *GameUiManager) play(card cards.Card) error {
+	if ui.Client != nil {
+		return ui.Client.Play(card)
+	}
+	err := ui.Game.Play(ui.DevicePlayer.Direction, card)
+	if err != nil {
+		return err
+	}
+	return ui.afterAction()
+}
+
+// Collects the complete trick, or asks the host to when the game was joined on another device
+func (ui *GameUiManager) claim() error {
+	if ui.Client != nil {
+		return ui.Client.Claim()
+	}
+	_, err := ui.Game.Collect()
+	if err != nil {
+		return err
+	}
+	return ui.afterAction()
+}
+
+// Updates the players after any change to the game, sends the game to the clients and scores the hand as
+// soon as its last trick is collected, showing the result
+func (ui *GameUiManager) afterAction() error {
+	ui.lastAction = time.Now()
+	ui.lastChange = ui.lastAction
+	ui.sync()
+
+	if ui.Game.Phase == game.HandOver {
+		score, err := ui.Match.EndHand()
+		if err != nil {
+			return err
+		}
+		ui.ShowScoreboard = true
+		ui.recordHand(score)
+	}
+
+	if ui.Server != nil {
+		ui.Server.Broadcast()
+	}
+
+	err := ui.Save()
+	if err != nil {
+		fmt.Printf("ignoring save error %q\n", err)
+	}
+	return nil
+}
+
+// Returns the last time the table changed without the device player doing anything, for example because a
+// bot played, the host sent the game or a line of chat was received
+func (ui *GameUiManager) LastChange() time.Time {
+	return ui.lastChange
+}
+
+// Writes the hand that was just scored to RecordDir, once. Only the games played or hosted by this device are
+// recorded since the other devices do not know every hand
+func (ui *GameUiManager) recordHand(score scoring.HandScore) {
+	if ui.RecordDir == "" || ui.Client != nil || ui.recorded == ui.Game {
+		return
+	}
+	ui.recorded = ui.Game
+
+	r := record.New(ui.Game)
+	r.GameId = ui.GameId
+	r.Hand = len(ui.Match.Score.Hands)
+	r.Seat = ui.DevicePlayer.Direction
+	r.Score = &score
+	r.Players = make(map[int]string)
+	for player := range ui.Players {
+		if player.Name != "" {
+			r.Players[player.Direction] = player.Name
+		}
+	}
+
+	_, err := record.WriteFile(ui.RecordDir, r)
+	if err != nil {
+		fmt.Printf("ignoring record error %q\n", err)
+	}
+}
+
+// Copies the state of the game back onto the players of the game
+func (ui *GameUiManager) sync() {
+	for player := range ui.Players {
+		player.Cards = ui.Game.Hand(player.Direction)
+		if player.Direction == ui.Game.Current() {
+			ui.CurrentPlayer = player
+		}
+	}
+}
+
+// Returns the cards held by the device player
+func (ui *GameUiManager) Hand() []cards.Card {
+	return ui.Game.Hand(ui.DevicePlayer.Direction)
 }
 
 func (ui *GameUiManager) AddNewPlayer(player *interfaces.Player) {
@@ -160,12 +730,133 @@ func (ui *GameUiManager) SetCurrentPlayer(player *interfaces.Player) error {
 	return nil
 }
 
-func (ui *GameUiManager) StartGame() {
+// Returns the player seated at the given direction, or nil if the seat is empty
+func (ui *GameUiManager) PlayerAt(direction int) *interfaces.Player {
+	context := interfaces.GameContext{Players: ui.Players}
+	return context.PlayerAt(direction)
+}
+
+// Moves the device player to another seat before the game starts. The player sitting there, and the bot
+// playing for it if any, take the seat left by the device player. Players that joined from another device may
+// only take a free seat, the host decides.
+func (ui *GameUiManager) TakeSeat(direction int) error {
+	if ui.GameStarted {
+		return errors.New("seat error: the game has already started")
+	}
+	if ui.Client != nil {
+		return ui.Client.TakeSeat(direction)
+	}
+
+	if ui.Server != nil {
+		err := ui.Server.MoveHost(direction)
+		if err != nil {
+			return err
+		}
+	}
+
+	from := ui.DevicePlayer.Direction
+	if other := ui.PlayerAt(direction); other != nil {
+		other.Direction = from
+	}
+	ui.DevicePlayer.Direction = direction
+
+	fromBot, fromOk := ui.Bots[from]
+	toBot, toOk := ui.Bots[direction]
+	delete(ui.Bots, from)
+	delete(ui.Bots, direction)
+	if fromOk {
+		ui.Bots[direction] = fromBot
+	}
+	if toOk {
+		ui.Bots[from] = toBot
+	}
+	return ui.afterLobbyChange()
+}
+
+// Changes the name the other players see
+func (ui *GameUiManager) SetName(name string) error {
+	ui.DevicePlayer.Name = name
+	if ui.Client != nil {
+		return ui.Client.Rename(name)
+	}
+	if ui.Server != nil {
+		ui.Server.HostName = name
+	}
+	return ui.afterLobbyChange()
+}
+
+// Tells the host whether the device player is ready for the game to start. The host itself is always ready
+func (ui *GameUiManager) SetReady(ready bool) error {
+	if ui.Client == nil {
+		return nil
+	}
+	ui.DevicePlayer.Ready = ready
+	return ui.Client.Ready(ready)
+}
+
+// Reports whether the device may start the game, that is whether it hosts the game or plays it alone and
+// every person seated is ready
+func (ui *GameUiManager) CanStart() bool {
+	if ui.Client != nil || ui.GameStarted {
+		return false
+	}
+	return ui.Server == nil || ui.Server.AllReady()
+}
+
+// Starts the game set up in the lobby and deals its first hand
+func (ui *GameUiManager) StartGame() error {
+	if ui.Client != nil {
+		return errors.New("start error: only the host can start the game")
+	}
+	if !ui.CanStart() {
+		return errors.New("start error: not every player is ready")
+	}
+
 	ui.GameStarted = true
+	if ui.Server != nil {
+		ui.Server.Started = true
+	}
+	return ui.NewGame()
+}
+
+// Gets back to the lobby, where the players may change seats until StartGame is called again
+func (ui *GameUiManager) OpenLobby() error {
+	ui.GameStarted = false
+	ui.ShowScoreboard = false
+	ui.selectedCard = cards.Card{}
+	if ui.Server != nil {
+		ui.Server.Started = false
+	}
+	return ui.afterLobbyChange()
+}
+
+// Copies who sits where from the host onto the players and tells the clients about any change made by the
+// device player
+func (ui *GameUiManager) afterLobbyChange() error {
+	if ui.Server == nil {
+		return nil
+	}
+	ui.applyLobby(ui.Server.Lobby(), ui.Server.HostSeat)
+	ui.Server.Broadcast()
+	return nil
 }
 
-func (ui *GameUiManager) AssignCards(cards []string) {
-	ui.Cards = cards
+// Copies the seats of the lobby onto the players. The players keep their directions, only their names and
+// states change, along with which of them plays on this device
+func (ui *GameUiManager) applyLobby(lobby []protocol.LobbySeat, deviceSeat int) {
+	for _, seat := range lobby {
+		player := ui.PlayerAt(seat.Seat)
+		if player == nil {
+			continue
+		}
+		player.Name, player.Ready, player.IsHost, player.Away = seat.Name, seat.Ready, seat.Host, seat.Away
+		if seat.Host {
+			ui.Host = player
+		}
+		if seat.Seat == deviceSeat {
+			ui.DevicePlayer = player
+		}
+	}
 }
 
 func (ui *GameUiManager) Draw(
@@ -178,7 +869,7 @@ func (ui *GameUiManager) Draw(
 		return err
 	}
 
-	if ui.CurrentPlayer == ui.DevicePlayer {
+	if ui.CurrentPlayer == ui.DevicePlayer && ui.Game.Phase == game.Playing {
 		err = ui.drawPlayButton(firstCardY-100, renderer)
 		if err != nil {
 			return err
@@ -200,19 +891,63 @@ func (ui *GameUiManager) Draw(
 		return err
 	}
 
+	err = ui.drawTrump(renderer)
+	if err != nil {
+		return err
+	}
+
+	err = scoreButton.Draw(50, 50+claimedHandsText.Height+trumpText.Height, renderer)
+	if err != nil {
+		return err
+	}
+
+	if ui.Client != nil && !ui.Client.Connected() {
+		err = connectionText.Draw((winWidth-connectionText.Width)/2, 50, renderer)
+		if err != nil {
+			return err
+		}
+	}
+
 	if ui.DevicePlayer == ui.Host {
 		err = ui.drawNewGameButton(winWidth, renderer)
 		if err != nil {
 			return err
 		}
+
+		if _, over := ui.Match.Over(); ui.Game.Phase == game.HandOver && !over {
+			err = nextHandButton.Draw((winWidth-nextHandButton.Width)/2, winHeight/2-nextHandButton.Height/2, renderer)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = nextHandButton.Draw(winWidth, winHeight, renderer)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
-	return nil
+	if time.Now().Before(ui.detailsUntil) && cards.Contains(ui.Hand(), ui.detailedCard) {
+		cardDetailsText.BtnText = ui.cardDetails(ui.detailedCard)
+		err = cardDetailsText.Draw((winWidth-cardDetailsText.Width)/2, firstCardY-cardDetailsText.Height-80, renderer)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The device player's own reaction is drawn above its cards
+	err = ui.drawReaction(ui.DevicePlayer, (winWidth-reactionText.Width)/2, firstCardY-reactionText.Height-15, renderer)
+	if err != nil {
+		return err
+	}
+
+	return ui.drawChat(winWidth, winHeight, renderer)
 }
 
 func (ui *GameUiManager) drawCardRack(w, h int32, renderer *sdl.Renderer) (int32, int32, error) {
 
-	if len(ui.Cards) == 0 {
+	hand := ui.Hand()
+	if len(hand) == 0 {
 		return 0, cardYPosition, nil
 	}
 
@@ -231,15 +966,15 @@ func (ui *GameUiManager) drawCardRack(w, h int32, renderer *sdl.Renderer) (int32
 		return 0, 0, err
 	}
 
-	imageW := allCards[ui.Cards[0]].Width
+	imageW := allCards[hand[0]].Width
 
-	intervals := generateCenteredIntervals(w, imageW, len(ui.Cards), 45)
+	intervals := generateCenteredIntervals(w, imageW, len(hand), 45)
 
 	for i, e := range intervals {
-		if ui.Cards[i] == ui.selectedCard {
-			err = allCards[ui.Cards[i]].Draw(e, h-rectHeight-100, renderer)
+		if hand[i] == ui.selectedCard {
+			err = allCards[hand[i]].Draw(e, h-rectHeight-100, renderer)
 		} else {
-			err = allCards[ui.Cards[i]].Draw(e, h-rectHeight, renderer)
+			err = allCards[hand[i]].Draw(e, h-rectHeight, renderer)
 		}
 
 		if err != nil {
@@ -249,8 +984,8 @@ func (ui *GameUiManager) drawCardRack(w, h int32, renderer *sdl.Renderer) (int32
 
 	// Explicitly draw the cards that are not used off the screen as they were invisibly taking on
 	// default values at the top edge
-	shownCards := make(map[string]bool)
-	for _, card := range ui.Cards {
+	shownCards := make(map[cards.Card]bool)
+	for _, card := range hand {
 		shownCards[card] = true
 	}
 	for key, value := range allCards {
@@ -267,9 +1002,25 @@ func (ui *GameUiManager) drawCardRack(w, h int32, renderer *sdl.Renderer) (int32
 	return intervals[0], cardYPosition, nil
 }
 
+// Returns the details shown for a card long pressed, for example "Queen of Spades, trump, cannot be played"
+func (ui *GameUiManager) cardDetails(card cards.Card) string {
+	details := card.Name()
+	if card.Suit == ui.Game.Trump {
+		details += ", trump"
+	}
+	if ui.Game.Phase == game.Playing && ui.CurrentPlayer == ui.DevicePlayer {
+		if cards.Contains(ui.Game.LegalMoves(ui.DevicePlayer.Direction), card) {
+			details += ", playable"
+		} else {
+			details += ", cannot be played"
+		}
+	}
+	return details
+}
+
 func (ui *GameUiManager) drawPlayButton(firstCardY int32, renderer *sdl.Renderer) error {
 
-	if ui.selectedCard == "" {
+	if ui.selectedCard.IsZero() || !cards.Contains(ui.Game.LegalMoves(ui.DevicePlayer.Direction), ui.selectedCard) {
 		playButton.Color = utils.SILVER
 	} else {
 		playButton.Color = utils.GREEN
@@ -329,7 +1080,7 @@ func (ui *GameUiManager) drawOpponentsAndPlayedCards(w, h int32, renderer *sdl.R
 				case 2:
 					// Draw Right
 					x, y := w-playerIcon.Width-15, h/2-50
-					imageX, imageY := x-allCards["c1"].Width-15, y-50
+					imageX, imageY := x-allCards[cards.New(cards.Clubs, cards.Ace)].Width-15, y-50
 					err := ui.drawPlayerIcon(player, x, y, renderer)
 					if err != nil {
 						return err
@@ -344,7 +1095,7 @@ func (ui *GameUiManager) drawOpponentsAndPlayedCards(w, h int32, renderer *sdl.R
 	}
 
 	// Draw player played card if any
-	if playedCard := ui.PlayedCards[ui.DevicePlayer.Direction]; playedCard != "" {
+	if playedCard := ui.playedCard(ui.DevicePlayer); !playedCard.IsZero() {
 		leftX, leftY := int32(15), h/2-50
 		imageX, imageY := leftX+playerIcon.Width+110, leftY+playerIcon.Height/2
 		err := ui.drawPlayedCard(ui.DevicePlayer, imageX, imageY, renderer)
@@ -368,11 +1119,27 @@ func (ui *GameUiManager) drawPlayerIcon(player *interfaces.Player, x, y int32, r
 		return err
 	}
 
-	return nil
+	if bid, ok := ui.Game.BidOf(player.Direction); ok {
+		bidText.BtnText = "Bid: " + variants.BidString(bid)
+		err = bidText.Draw(x, y+playerIcon.Height+5, renderer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ui.drawReaction(player, x, y-reactionText.Height-5, renderer)
+}
+
+// Returns the card the player has played to the current trick, if any
+func (ui *GameUiManager) playedCard(player *interfaces.Player) cards.Card {
+	if ui.Game.Table == nil {
+		return cards.Card{}
+	}
+	return ui.Game.Table.Trick.CardOf(player.Direction)
 }
 
 func (ui *GameUiManager) drawPlayedCard(player *interfaces.Player, imageX int32, imageY int32, renderer *sdl.Renderer) error {
-	if playedCard := ui.PlayedCards[player.Direction]; playedCard != "" {
+	if playedCard := ui.playedCard(player); !playedCard.IsZero() {
 		err := allCards[playedCard].Draw(imageX, imageY, renderer)
 		if err != nil {
 			return err
@@ -382,6 +1149,11 @@ func (ui *GameUiManager) drawPlayedCard(player *interfaces.Player, imageX int32,
 }
 
 func (ui *GameUiManager) drawClaimButton(winWidth, cardY int32, renderer *sdl.Renderer) error {
+	if ui.Game.Table != nil && ui.Game.Table.TrickComplete() {
+		claimButton.Color = utils.GREEN
+	} else {
+		claimButton.Color = utils.SILVER
+	}
 	err := claimButton.Draw(winWidth-claimButton.Width-20, cardY-125, renderer)
 	if err != nil {
 		return err
@@ -391,7 +1163,15 @@ func (ui *GameUiManager) drawClaimButton(winWidth, cardY int32, renderer *sdl.Re
 }
 
 func (ui *GameUiManager) drawClaimedHands(renderer *sdl.Renderer) error {
-	claimedHandsText.BtnText = "Claimed: " + strconv.Itoa(ui.claimedHands)
+	claimed := 0
+	if ui.Game.Table != nil {
+		claimed = ui.Game.Table.TricksWon[ui.DevicePlayer.Direction]
+	}
+
+	claimedHandsText.BtnText = "Claimed: " + strconv.Itoa(claimed)
+	if bid, ok := ui.Game.BidOf(ui.DevicePlayer.Direction); ok {
+		claimedHandsText.BtnText += " / " + variants.BidString(bid)
+	}
 	err := claimedHandsText.Draw(50, 50, renderer)
 	if err != nil {
 		return err
@@ -400,24 +1180,17 @@ func (ui *GameUiManager) drawClaimedHands(renderer *sdl.Renderer) error {
 	return nil
 }
 
-func (ui *GameUiManager) removeSelectedCard() {
-	newCards := make([]string, len(ui.Cards) - 1)
-	index := 0
-	for _, e := range ui.Cards {
-		if e != ui.selectedCard {
-			newCards[index] = e
-			index++
-		}
-	}
-	ui.Cards = newCards
+func (ui *GameUiManager) drawTrump(renderer *sdl.Renderer) error {
+	trumpText.BtnText = "Trump: " + ui.Game.Trump.String()
+	return trumpText.Draw(50, 50+claimedHandsText.Height, renderer)
 }
 
 func (ui *GameUiManager) drawNewGameButton(width int32, renderer *sdl.Renderer) error {
-	return newGameButton.Draw((width - newGameButton.Width)/2, 50, renderer)
+	return newGameButton.Draw((width-newGameButton.Width)/2, 50, renderer)
 }
 
-func GetCard(card string, manager *imgmanager.ImageManager) *sdl.Texture {
-	return manager.Images["cards/fronts/"+card]
+func GetCard(card cards.Card, manager *imgmanager.ImageManager) *sdl.Texture {
+	return manager.Images[card.TextureKey()]
 }
 
 func generateCenteredIntervals(width, cardWidth int32, count int, delta int32) []int32 {
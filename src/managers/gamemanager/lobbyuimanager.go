@@ -0,0 +1,244 @@
+package gamemanager
+
+import (
+	"CardGameGo/src/components/buttons/rectbutton"
+	"CardGameGo/src/components/textinput"
+	"CardGameGo/src/managers/eventmanager"
+	"CardGameGo/src/managers/fontmanager"
+	"CardGameGo/src/utils"
+	"fmt"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// The longest name the device player may type, in characters
+const maxNameLength = 20
+
+var seatButtons = make(map[int]*rectbutton.RectangularButton)
+var nameInput *textinput.TextInput = nil
+var readyButton *rectbutton.RectangularButton = nil
+var startButton *rectbutton.RectangularButton = nil
+var openHandsButton *rectbutton.RectangularButton = nil
+var lobbyTitleText *rectbutton.RectangularButton = nil
+
+// The ui of the lobby, shown before the game starts. It lists the seats of the table with the player sitting
+// at each of them. The device player picks a seat by tapping it, changes its name and, when it joined the
+// game from another device, tells the host it is ready. The host starts the game once everyone is ready.
+type LobbyUiManager struct {
+	// The ui manager of the game being set up
+	GameUi *GameUiManager
+}
+
+// Provided constructor
+func NewLobby(gameUi *GameUiManager) *LobbyUiManager {
+	return &LobbyUiManager{
+		GameUi: gameUi,
+	}
+}
+
+func seatCallBackGenerator(lui *LobbyUiManager, direction int) func(...interface{}) error {
+	return func(...interface{}) error {
+		err := lui.stopEditing()
+		if err != nil {
+			return err
+		}
+		if direction == lui.GameUi.DevicePlayer.Direction {
+			return nil
+		}
+		return lui.GameUi.TakeSeat(direction)
+	}
+}
+
+func (lui *LobbyUiManager) Init(eventManager *eventmanager.EventManager, fontManager *fontmanager.FontManager) {
+	font, _ := fontManager.GetFont("universalfruitcake", 20)
+
+	// Init seat buttons
+	for _, direction := range utils.DirectionOrder {
+		seatButtons[direction] = rectbutton.New("", 450, 75, utils.GRAY, font)
+		seatButtons[direction].CallBack = seatCallBackGenerator(lui, direction)
+	}
+
+	// Init name input. The name typed is kept when return is pressed or another button of the lobby is tapped
+	nameInput = textinput.New("Your name", 450, 75, utils.GRAY, font)
+	nameInput.MaxLength = maxNameLength
+	nameInput.OnSubmit = func(name string) error {
+		if name == "" || name == lui.GameUi.DevicePlayer.Name {
+			return nil
+		}
+		return lui.GameUi.SetName(name)
+	}
+
+	// Init ready button, only drawn for the players that joined from another device
+	readyButton = rectbutton.New("", 250, 75, utils.GRAY, font)
+	readyButton.CallBack = func(i ...interface{}) error {
+		err := lui.stopEditing()
+		if err != nil {
+			return err
+		}
+		return lui.GameUi.SetReady(!lui.GameUi.DevicePlayer.Ready)
+	}
+
+	// Init start button, only drawn for the host
+	startButton = rectbutton.New("Start", 250, 75, utils.GREEN, font)
+	startButton.CallBack = func(i ...interface{}) error {
+		err := lui.stopEditing()
+		if err != nil {
+			return err
+		}
+		if !lui.GameUi.CanStart() {
+			return nil
+		}
+		return lui.GameUi.StartGame()
+	}
+
+	// Init open hands button, only drawn for the host of a game other devices can join. Every click shows or
+	// hides the hands to the spectators
+	openHandsButton = rectbutton.New("", 450, 75, utils.GRAY, font)
+	openHandsButton.CallBack = func(i ...interface{}) error {
+		err := lui.stopEditing()
+		if err != nil {
+			return err
+		}
+		lui.GameUi.SetOpenHands(!lui.GameUi.OpenHands())
+		return nil
+	}
+
+	// Init title text
+	lobbyTitleText = rectbutton.New("", 450, 50, &sdl.Color{R: 66, G: 152, B: 66, A: 255}, font)
+
+	lui.Register(eventManager)
+}
+
+// Registers the seats and the buttons of the lobby with the event manager of the lobby screen. Refer to
+// GameUiManager.Register
+func (lui *LobbyUiManager) Register(eventManager *eventmanager.EventManager) {
+	for _, direction := range utils.DirectionOrder {
+		eventManager.RegisterEvent(seatButtons[direction])
+	}
+	eventManager.RegisterEvent(nameInput)
+	eventManager.RegisterEvent(readyButton)
+	eventManager.RegisterEvent(startButton)
+	eventManager.RegisterEvent(openHandsButton)
+}
+
+// Gives the name being typed, if any, to the device player. An empty name is ignored
+func (lui *LobbyUiManager) stopEditing() error {
+	if !nameInput.Focused() {
+		return nil
+	}
+	return nameInput.Submit()
+}
+
+// Reports whether the game started and the game screen should be shown instead
+func (lui *LobbyUiManager) Done() bool {
+	return lui.GameUi.GameStarted
+}
+
+func (lui *LobbyUiManager) Draw(winWidth, winHeight int32, renderer *sdl.Renderer) error {
+	ui := lui.GameUi
+	x := (winWidth - seatButtons[utils.North].Width) / 2
+	y := winHeight/2 - 350
+
+	lobbyTitleText.BtnText = "Lobby: " + ui.Match.Variant.Name()
+	err := lobbyTitleText.Draw((winWidth-lobbyTitleText.Width)/2, y, renderer)
+	if err != nil {
+		return err
+	}
+	y += lobbyTitleText.Height + 25
+
+	// Draw a button per seat, the seat of the device player being highlighted
+	for _, direction := range utils.DirectionOrder {
+		button := seatButtons[direction]
+		button.BtnText = lui.seatLabel(direction)
+		if direction == ui.DevicePlayer.Direction {
+			button.Color = utils.GREEN
+		} else {
+			button.Color = utils.GRAY
+		}
+		err = button.Draw(x, y, renderer)
+		if err != nil {
+			return err
+		}
+		y += button.Height + 15
+	}
+	y += 25
+
+	if nameInput.Focused() {
+		nameInput.Color = utils.SILVER
+	} else {
+		nameInput.Text = ui.DevicePlayer.Name
+		nameInput.Color = utils.GRAY
+	}
+	err = nameInput.Draw(x, y, renderer)
+	if err != nil {
+		return err
+	}
+	y += nameInput.Height + 15
+
+	if ui.Server != nil {
+		openHandsButton.BtnText = "Spectators see hands: No"
+		if ui.OpenHands() {
+			openHandsButton.BtnText = "Spectators see hands: Yes"
+		}
+		err = openHandsButton.Draw(x, y, renderer)
+		y += openHandsButton.Height + 15
+	} else {
+		err = openHandsButton.Draw(winWidth, winHeight, renderer)
+	}
+	if err != nil {
+		return err
+	}
+	y += 25
+
+	// The players that joined from another device get ready, the host starts the game. The other button is
+	// hidden by being drawn off the screen
+	if ui.Client != nil {
+		if ui.DevicePlayer.Ready {
+			readyButton.BtnText = "Not Ready"
+			readyButton.Color = utils.SILVER
+		} else {
+			readyButton.BtnText = "Ready"
+			readyButton.Color = utils.GREEN
+		}
+		err = readyButton.Draw((winWidth-readyButton.Width)/2, y, renderer)
+		if err != nil {
+			return err
+		}
+		return startButton.Draw(winWidth, winHeight, renderer)
+	}
+
+	if ui.CanStart() {
+		startButton.Color = utils.GREEN
+	} else {
+		startButton.Color = utils.SILVER
+	}
+	err = startButton.Draw((winWidth-startButton.Width)/2, y, renderer)
+	if err != nil {
+		return err
+	}
+	return readyButton.Draw(winWidth, winHeight, renderer)
+}
+
+// Returns the text of the button of a seat, for example "North: Alice (ready)"
+func (lui *LobbyUiManager) seatLabel(direction int) string {
+	ui := lui.GameUi
+	player := ui.PlayerAt(direction)
+
+	name := "Bot"
+	if player != nil && player.Name != "" {
+		name = player.Name
+	}
+	if player != nil && player == ui.DevicePlayer {
+		name += " (you)"
+	}
+
+	label := fmt.Sprintf("%s: %s", utils.DirectionToString(direction), name)
+	switch {
+	case player != nil && player.IsHost:
+		label += " (host)"
+	case player != nil && player.Away:
+		label += " (away)"
+	case player != nil && player.Name != "" && player != ui.DevicePlayer && player.Ready:
+		label += " (ready)"
+	}
+	return label
+}
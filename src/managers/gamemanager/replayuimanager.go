@@ -0,0 +1,221 @@
+package gamemanager
+
+import (
+	"CardGameGo/src/components/buttons/rectbutton"
+	"CardGameGo/src/game"
+	"CardGameGo/src/managers/eventmanager"
+	"CardGameGo/src/managers/fontmanager"
+	"CardGameGo/src/managers/interfaces"
+	"CardGameGo/src/record"
+	"CardGameGo/src/utils"
+	"CardGameGo/src/variants"
+	"errors"
+	"fmt"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+var replayPreviousButton *rectbutton.RectangularButton = nil
+var replayBackButton *rectbutton.RectangularButton = nil
+var replayForwardButton *rectbutton.RectangularButton = nil
+var replayNextButton *rectbutton.RectangularButton = nil
+var replayTitleText *rectbutton.RectangularButton = nil
+var replayMoveText *rectbutton.RectangularButton = nil
+
+// The ui replaying the hands recorded by GameUiManager, refer to src/record. The hand is drawn move by move
+// with the same table as the game, seen from the seat of the device that recorded it, and the cards of the
+// other seats are listed under the table. The buttons step back and forth through the moves of a hand and
+// through the hands recorded.
+type ReplayUiManager struct {
+	// The directory the hands are recorded to. Refer to GameUiManager.RecordDir
+	Dir string
+
+	// The records of Dir, the oldest first, and the index of the one being replayed
+	paths []string
+	index int
+
+	// The hand being replayed, the game after every one of its moves and the move shown
+	record *record.Record
+	steps  []*game.Game
+	step   int
+
+	// Draws the hand being replayed. It is never initialised, it only borrows the cards of the game
+	view *GameUiManager
+}
+
+// Provided constructor
+func NewReplay(dir string) *ReplayUiManager {
+	return &ReplayUiManager{
+		Dir: dir,
+	}
+}
+
+// Registers the buttons of the replay. The GameUiManager of the game must have been initialised first since
+// the cards drawn are shared with it.
+func (rui *ReplayUiManager) Init(eventManager *eventmanager.EventManager, fontManager *fontmanager.FontManager) {
+	font, _ := fontManager.GetFont("universalfruitcake", 20)
+
+	replayPreviousButton = rectbutton.New("<<", 150, 75, utils.GREEN, font)
+	replayPreviousButton.CallBack = func(i ...interface{}) error {
+		return rui.load(rui.index - 1)
+	}
+
+	replayBackButton = rectbutton.New("<", 150, 75, utils.GREEN, font)
+	replayBackButton.CallBack = func(i ...interface{}) error {
+		if rui.step > 0 {
+			rui.step--
+		}
+		return nil
+	}
+
+	replayForwardButton = rectbutton.New(">", 150, 75, utils.GREEN, font)
+	replayForwardButton.CallBack = func(i ...interface{}) error {
+		if rui.step < len(rui.steps)-1 {
+			rui.step++
+		}
+		return nil
+	}
+
+	replayNextButton = rectbutton.New(">>", 150, 75, utils.GREEN, font)
+	replayNextButton.CallBack = func(i ...interface{}) error {
+		return rui.load(rui.index + 1)
+	}
+
+	replayTitleText = rectbutton.New("", 450, 50, utils.SILVER, font)
+	replayMoveText = rectbutton.New("", 450, 40, &sdl.Color{R: 168, G: 235, B: 254, A: 255}, font)
+
+	rui.Register(eventManager)
+}
+
+// Registers the buttons of the replay with the event manager of the replay screen. Refer to
+// GameUiManager.Register
+func (rui *ReplayUiManager) Register(eventManager *eventmanager.EventManager) {
+	eventManager.RegisterEvent(replayPreviousButton)
+	eventManager.RegisterEvent(replayBackButton)
+	eventManager.RegisterEvent(replayForwardButton)
+	eventManager.RegisterEvent(replayNextButton)
+}
+
+// Lists the hands recorded and shows the most recent one from its deal
+func (rui *ReplayUiManager) Open() error {
+	paths, err := record.List(rui.Dir)
+	if err != nil {
+		return err
+	}
+	rui.paths = paths
+	rui.record = nil
+	if len(paths) == 0 {
+		return nil
+	}
+	return rui.load(len(paths) - 1)
+}
+
+// Replays the hand of the given record of Dir from its deal. Nothing changes when there is no such record
+func (rui *ReplayUiManager) load(index int) error {
+	if index < 0 || index >= len(rui.paths) {
+		return nil
+	}
+
+	r, err := record.ReadFile(rui.paths[index])
+	if err != nil {
+		return err
+	}
+	steps, err := r.Steps()
+	if err != nil {
+		return err
+	}
+	variant, ok := variants.ByName(r.Variant)
+	if !ok {
+		return errors.New(fmt.Sprintf("replay error: unknown variant %q", r.Variant))
+	}
+
+	players := make(map[*interfaces.Player]bool)
+	var device *interfaces.Player
+	for _, direction := range utils.DirectionOrder {
+		player := &interfaces.Player{Name: r.Players[direction], Direction: direction}
+		if direction == r.Seat {
+			device = player
+		}
+		players[player] = true
+	}
+
+	rui.view = New(device, interfaces.GameContext{Players: players, Host: device, Variant: variant})
+	rui.index = index
+	rui.record = r
+	rui.steps = steps
+	rui.step = 0
+	return nil
+}
+
+func (rui *ReplayUiManager) Draw(winWidth, winHeight int32, renderer *sdl.Renderer) error {
+	if rui.record == nil {
+		replayTitleText.BtnText = "No hands recorded yet"
+		err := replayTitleText.Draw((winWidth-replayTitleText.Width)/2, winHeight/2, renderer)
+		if err != nil {
+			return err
+		}
+		return rui.hideButtons(winWidth, winHeight, renderer)
+	}
+
+	ui := rui.view
+	ui.Game = rui.steps[rui.step]
+	ui.sync()
+
+	_, firstCardY, err := ui.drawCardRack(winWidth, winHeight, renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawOpponentsAndPlayedCards(winWidth, winHeight, renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawOpenHands(winHeight/2+150, renderer)
+	if err != nil {
+		return err
+	}
+
+	replayTitleText.BtnText = fmt.Sprintf("%s, hand %d (%d/%d)", rui.record.Variant, rui.record.Hand, rui.index+1,
+		len(rui.paths))
+	err = replayTitleText.Draw((winWidth-replayTitleText.Width)/2, 50, renderer)
+	if err != nil {
+		return err
+	}
+
+	replayMoveText.BtnText = "Deal, trump: " + ui.Game.Trump.String()
+	if rui.step > 0 {
+		move := rui.record.Moves[rui.step-1]
+		replayMoveText.BtnText = fmt.Sprintf("%d/%d: %s", rui.step, len(rui.record.Moves), move)
+	}
+	err = replayMoveText.Draw((winWidth-replayMoveText.Width)/2, 50+replayTitleText.Height+5, renderer)
+	if err != nil {
+		return err
+	}
+
+	// The buttons are lined up above the cards, from the previous hand to the next
+	buttons := []*rectbutton.RectangularButton{replayPreviousButton, replayBackButton, replayForwardButton,
+		replayNextButton}
+	width := int32(len(buttons))*replayBackButton.Width + int32(len(buttons)-1)*15
+	x := (winWidth - width) / 2
+	for _, button := range buttons {
+		err = button.Draw(x, firstCardY-button.Height-25, renderer)
+		if err != nil {
+			return err
+		}
+		x += button.Width + 15
+	}
+	return nil
+}
+
+// Draws the buttons off the screen so that they cannot be clicked while there is nothing to replay
+func (rui *ReplayUiManager) hideButtons(w, h int32, renderer *sdl.Renderer) error {
+	for _, button := range []*rectbutton.RectangularButton{replayPreviousButton, replayBackButton,
+		replayForwardButton, replayNextButton} {
+
+		err := button.Draw(w, h, renderer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package gamemanager
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// The version of the save file format. Files written by another version are not resumed
+const saveVersion = 1
+
+// A game of this device saved to a file so that it can be resumed after the application was closed, for
+// example when Android kills it while it is in the background. Refer to Save and Resume
+type SavedGame struct {
+	Version int    `json:"version"`
+	GameId  string `json:"gameId"`
+
+	// The seat of the device player
+	Seat int `json:"seat"`
+
+	// The whole match, every hand included. Refer to game.Snapshot
+	Match *game.Snapshot `json:"match"`
+}
+
+// Writes the game to SavePath. Only the games played or hosted by this device are saved, a game joined on
+// another device is saved by its host. The file is removed once the match is over since there is nothing
+// left to resume.
+//
+// The game is saved after every change, refer to afterAction, which also covers the application going to the
+// background: Android may suspend it before the event telling so is even polled, and saving the game again
+// then would only write what was written already.
+func (ui *GameUiManager) Save() error {
+	if ui.SavePath == "" || ui.Client != nil || !ui.GameStarted {
+		return nil
+	}
+	if _, over := ui.Match.Over(); over {
+		return RemoveSave(ui.SavePath)
+	}
+
+	data, err := json.Marshal(&SavedGame{
+		Version: saveVersion,
+		GameId:  ui.GameId,
+		Seat:    ui.DevicePlayer.Direction,
+		Match:   ui.Match.Snapshot(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The file is replaced at once so that the application being killed while writing it never leaves half
+	// a game behind
+	temp := ui.SavePath + ".tmp"
+	err = ioutil.WriteFile(temp, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(temp, ui.SavePath)
+}
+
+// Reports whether a game was saved to the file
+func HasSave(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Removes the game saved to the file, if any
+func RemoveSave(path string) error {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Reads the game saved to the file by Save
+func LoadGame(path string) (*SavedGame, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved SavedGame
+	err = json.Unmarshal(data, &saved)
+	if err != nil {
+		return nil, err
+	}
+	if saved.Version != saveVersion {
+		return nil, errors.New(fmt.Sprintf("load error: unsupported save version %d", saved.Version))
+	}
+	if saved.Match == nil {
+		return nil, errors.New("load error: the match is missing")
+	}
+	return &saved, nil
+}
+
+// Gets back to a saved game, leaving the game being played. The game is resumed on this device alone: the
+// players of other devices that joined a hosted game are not there anymore, their seats are left to bots.
+// Refer to SetBot
+func (ui *GameUiManager) Resume(saved *SavedGame) error {
+	match, err := saved.Match.Match()
+	if err != nil {
+		return err
+	}
+
+	err = ui.LeaveGame()
+	if err != nil {
+		fmt.Printf("ignoring leave error %q\n", err)
+	}
+	err = ui.StopHosting()
+	if err != nil {
+		fmt.Printf("ignoring host error %q\n", err)
+	}
+
+	// The device player takes its seat back, the other players are bots
+	if other := ui.PlayerAt(saved.Seat); other != nil && other != ui.DevicePlayer {
+		other.Direction = ui.DevicePlayer.Direction
+	}
+	ui.DevicePlayer.Direction = saved.Seat
+	for player := range ui.Players {
+		if player != ui.DevicePlayer {
+			player.Name, player.Ready, player.IsHost, player.Away = "", false, false, false
+		}
+	}
+	ui.DevicePlayer.IsHost = true
+	ui.Host = ui.DevicePlayer
+
+	ui.GameId = saved.GameId
+	ui.Match = match
+	ui.Game = match.Hand
+	ui.GameStarted = true
+	ui.ShowScoreboard = ui.Game.Phase == game.HandOver
+	ui.ShowChat = false
+	ui.selectedCard = cards.Card{}
+	ui.lastAction = time.Now()
+	ui.sync()
+	return nil
+}
@@ -0,0 +1,134 @@
+package gamemanager
+
+import (
+	"CardGameGo/src/components/buttons/rectbutton"
+	"CardGameGo/src/components/text"
+	"CardGameGo/src/managers/eventmanager"
+	"CardGameGo/src/managers/fontmanager"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils"
+	"CardGameGo/src/variants"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+	"strconv"
+)
+
+// The number of most recent hands listed on the scoreboard
+const scoreboardHands = 8
+
+// The height of a single line of the scoreboard
+const scoreboardLine = 45
+
+var closeScoreboardButton *rectbutton.RectangularButton = nil
+var scoreboardFont *ttf.Font = nil
+
+func (ui *GameUiManager) initScoreboard(fontManager *fontmanager.FontManager) {
+	scoreboardFont, _ = fontManager.GetFont("universalfruitcake", 20)
+
+	closeScoreboardButton = rectbutton.New("Close", 200, 70, utils.GREEN, scoreboardFont)
+	closeScoreboardButton.CallBack = func(i ...interface{}) error {
+		ui.ShowScoreboard = false
+		return nil
+	}
+}
+
+// Registers the button closing the scoreboard with the event manager of the overlay showing it
+func (ui *GameUiManager) RegisterScoreboard(eventManager *eventmanager.EventManager) {
+	eventManager.RegisterEvent(closeScoreboardButton)
+}
+
+// Draws the score of every hand of the match along with the running totals on top of the table
+func (ui *GameUiManager) DrawScoreboard(w, h int32, renderer *sdl.Renderer) error {
+	board := sdl.Rect{X: 40, Y: 200, W: w - 80, H: h - 450}
+	_ = renderer.SetDrawColor(utils.GRAY.R, utils.GRAY.G, utils.GRAY.B, 255)
+	err := renderer.FillRect(&board)
+	if err != nil {
+		return err
+	}
+
+	score := ui.Match.Score
+	variant := ui.Match.Variant
+	columnWidth := (board.W - 140) / int32(len(score.Teams))
+	columnX := func(i int) int32 {
+		return board.X + 140 + int32(i)*columnWidth
+	}
+
+	y := board.Y + 20
+	err = drawLabel(variant.Name()+" to "+strconv.Itoa(variant.Target()), board.X+20, y, renderer)
+	if err != nil {
+		return err
+	}
+
+	// Header row with the name of every team
+	y += scoreboardLine
+	err = drawLabel("Hand", board.X+20, y, renderer)
+	if err != nil {
+		return err
+	}
+	for i, team := range score.Teams {
+		err = drawLabel(scoring.TeamName(team), columnX(i), y, renderer)
+		if err != nil {
+			return err
+		}
+	}
+
+	// A row per hand, only showing the most recent ones
+	first := len(score.Hands) - scoreboardHands
+	if first < 0 {
+		first = 0
+	}
+	for n := first; n < len(score.Hands); n++ {
+		y += scoreboardLine
+		err = drawLabel(strconv.Itoa(n+1), board.X+20, y, renderer)
+		if err != nil {
+			return err
+		}
+		for i, team := range score.Hands[n].Teams {
+			line := strconv.Itoa(team.Points) + " (" + strconv.Itoa(team.Tricks) + ")"
+			err = drawLabel(line, columnX(i), y, renderer)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Running totals, with the bags carried towards the next penalty for the variants that count them
+	y += scoreboardLine * 3 / 2
+	err = drawLabel("Total", board.X+20, y, renderer)
+	if err != nil {
+		return err
+	}
+	for i, total := range score.Totals {
+		line := strconv.Itoa(total)
+		if score.Bags[i] > 0 {
+			line += " / " + strconv.Itoa(score.Bags[i]%variants.BagLimit) + " bags"
+		}
+		err = drawLabel(line, columnX(i), y, renderer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if winner, over := ui.Match.Over(); over {
+		y += scoreboardLine * 3 / 2
+		err = drawLabel(scoring.TeamName(score.Teams[winner])+" won the match!", board.X+20, y, renderer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return closeScoreboardButton.Draw((w-closeScoreboardButton.Width)/2,
+		board.Y+board.H-closeScoreboardButton.Height-20, renderer)
+}
+
+// Draws a single line of black text with its top left corner at the given position
+func drawLabel(line string, x, y int32, renderer *sdl.Renderer) error {
+	texture, err := text.New(line, scoreboardFont, renderer, sdl.Color{})
+	if err != nil {
+		return err
+	}
+	defer texture.Destroy()
+
+	_, _, tW, tH, _ := texture.Query()
+	return renderer.Copy(texture, nil, &sdl.Rect{X: x, Y: y, W: tW, H: tH})
+}
@@ -0,0 +1,144 @@
+package gamemanager
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/components/buttons/rectbutton"
+	"CardGameGo/src/managers/eventmanager"
+	"CardGameGo/src/managers/fontmanager"
+	"CardGameGo/src/utils"
+	"github.com/veandco/go-sdl2/sdl"
+	"strings"
+)
+
+var spectatorStatusText *rectbutton.RectangularButton = nil
+
+// The read only ui of a device watching a game hosted on another device. It draws the same table as the
+// GameUiManager it was created from (player icons, played cards, bids, scores and whose turn it is) seen from
+// the seat of the host, but never registers the play, claim or card click handlers so that nothing can be
+// played from it. The hands are only drawn when the host opened them.
+type SpectatorUiManager struct {
+	// The ui manager of the game being watched. Its Client must be a spectator, refer to network.Watch
+	GameUi *GameUiManager
+}
+
+// Provided constructor
+func NewSpectator(gameUi *GameUiManager) *SpectatorUiManager {
+	return &SpectatorUiManager{
+		GameUi: gameUi,
+	}
+}
+
+// Inits the status text and registers the buttons of the spectator. The GameUi must have been initialised
+// first since the buttons are shared with it.
+func (sui *SpectatorUiManager) Init(eventManager *eventmanager.EventManager, fontManager *fontmanager.FontManager) {
+	font, _ := fontManager.GetFont("universalfruitcake", 20)
+
+	// Init status text
+	spectatorStatusText = rectbutton.New("", 400, 50, utils.SILVER, font)
+
+	sui.Register(eventManager)
+}
+
+// Registers the only buttons a spectator may use, the ones showing the scoreboard and the chat,
+// with the event manager of the spectator screen. Refer to GameUiManager.Register
+func (sui *SpectatorUiManager) Register(eventManager *eventmanager.EventManager) {
+	eventManager.RegisterEvent(scoreButton)
+	sui.GameUi.registerChat(eventManager, false)
+}
+
+// Reports whether the device stopped watching the game, for example because the connection to the host was
+// lost for good
+func (sui *SpectatorUiManager) Done() bool {
+	return !sui.GameUi.Spectating()
+}
+
+func (sui *SpectatorUiManager) Draw(winWidth, winHeight int32, renderer *sdl.Renderer) error {
+	ui := sui.GameUi
+
+	if !ui.GameStarted {
+		spectatorStatusText.BtnText = "Waiting for the host to start"
+		return spectatorStatusText.Draw((winWidth-spectatorStatusText.Width)/2, winHeight/2, renderer)
+	}
+
+	// The hand of the seat the table is seen from is only known when the hands are open
+	_, _, err := ui.drawCardRack(winWidth, winHeight, renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawOpponentsAndPlayedCards(winWidth, winHeight, renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawOpenHands(winHeight/2+150, renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawClaimedHands(renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawTrump(renderer)
+	if err != nil {
+		return err
+	}
+
+	err = scoreButton.Draw(50, 50+claimedHandsText.Height+trumpText.Height, renderer)
+	if err != nil {
+		return err
+	}
+
+	// The seat the table is seen from has no icon, its reaction is drawn above its cards
+	err = ui.drawReaction(ui.DevicePlayer, (winWidth-reactionText.Width)/2, cardYPosition-reactionText.Height-15, renderer)
+	if err != nil {
+		return err
+	}
+
+	err = ui.drawChat(winWidth, winHeight, renderer)
+	if err != nil {
+		return err
+	}
+
+	if ui.Client.Connected() {
+		spectatorStatusText.BtnText = "Watching"
+	} else {
+		spectatorStatusText.BtnText = "Reconnecting..."
+	}
+	return spectatorStatusText.Draw((winWidth-spectatorStatusText.Width)/2, 50, renderer)
+}
+
+// Lists the cards of the seats other than the one the table is seen from, one line per seat starting at the
+// given height. The seats whose cards are not known are left out
+func (ui *GameUiManager) drawOpenHands(y int32, renderer *sdl.Renderer) error {
+	for _, direction := range utils.DirectionOrder {
+		if direction == ui.DevicePlayer.Direction {
+			continue
+		}
+		hand := ui.Game.Hand(direction)
+		if len(hand) == 0 {
+			continue
+		}
+
+		err := drawLabel(utils.DirectionToString(direction)+": "+handString(hand), 50, y, renderer)
+		if err != nil {
+			return err
+		}
+		y += scoreboardLine
+	}
+	return nil
+}
+
+// Formats the cards of a hand sorted by suit, for example "c2 c9 dQ s1"
+func handString(hand []cards.Card) string {
+	sorted := append([]cards.Card(nil), hand...)
+	cards.Sort(sorted)
+
+	names := make([]string, len(sorted))
+	for i, card := range sorted {
+		names[i] = card.String()
+	}
+	return strings.Join(names, " ")
+}
@@ -12,6 +12,7 @@
 package imgmanager
 
 import (
+	"CardGameGo/src/cards"
 	"errors"
 	"fmt"
 	"github.com/veandco/go-sdl2/img"
@@ -21,65 +22,12 @@ import (
 	"strings"
 )
 
-// All the images that will be used throughout the application are put here.
-var LOADED_IMAGES = []string{
+// All the images that will be used throughout the application are put here. The card faces are not listed by
+// hand, they are appended from the cards package so that the naming scheme only lives in one place.
+var LOADED_IMAGES = append([]string{
 	"cardicon.png",
 	"home.png",
-
-	"cards/fronts/c1.png",
-	"cards/fronts/c1.png",
-	"cards/fronts/c2.png",
-	"cards/fronts/c3.png",
-	"cards/fronts/c4.png",
-	"cards/fronts/c5.png",
-	"cards/fronts/c6.png",
-	"cards/fronts/c7.png",
-	"cards/fronts/c8.png",
-	"cards/fronts/c9.png",
-	"cards/fronts/cX.png",
-	"cards/fronts/cJ.png",
-	"cards/fronts/cQ.png",
-	"cards/fronts/cK.png",
-	"cards/fronts/h1.png",
-	"cards/fronts/h2.png",
-	"cards/fronts/h3.png",
-	"cards/fronts/h4.png",
-	"cards/fronts/h5.png",
-	"cards/fronts/h6.png",
-	"cards/fronts/h7.png",
-	"cards/fronts/h8.png",
-	"cards/fronts/h9.png",
-	"cards/fronts/hX.png",
-	"cards/fronts/hJ.png",
-	"cards/fronts/hQ.png",
-	"cards/fronts/hK.png",
-	"cards/fronts/d1.png",
-	"cards/fronts/d2.png",
-	"cards/fronts/d3.png",
-	"cards/fronts/d4.png",
-	"cards/fronts/d5.png",
-	"cards/fronts/d6.png",
-	"cards/fronts/d7.png",
-	"cards/fronts/d8.png",
-	"cards/fronts/d9.png",
-	"cards/fronts/dX.png",
-	"cards/fronts/dJ.png",
-	"cards/fronts/dQ.png",
-	"cards/fronts/dK.png",
-	"cards/fronts/s1.png",
-	"cards/fronts/s2.png",
-	"cards/fronts/s3.png",
-	"cards/fronts/s4.png",
-	"cards/fronts/s5.png",
-	"cards/fronts/s6.png",
-	"cards/fronts/s7.png",
-	"cards/fronts/s8.png",
-	"cards/fronts/s9.png",
-	"cards/fronts/sX.png",
-	"cards/fronts/sJ.png",
-	"cards/fronts/sQ.png",
-	"cards/fronts/sK.png",
-}
+}, cardImages()...)
 
 type ImageManager struct{
 	Images map[string]*sdl.Texture
@@ -121,3 +69,13 @@ func (i *ImageManager) Close() {
 		_ = texture.Destroy()
 	}
 }
+
+// Returns the image paths of the faces of every card in a standard deck
+func cardImages() []string {
+	all := cards.All()
+	images := make([]string, len(all))
+	for i, card := range all {
+		images[i] = card.ImagePath()
+	}
+	return images
+}
@@ -1,7 +1,71 @@
 package interfaces
 
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"errors"
+	"fmt"
+)
+
 type GameContext struct {
 	GameId  string
 	Players map[*Player]bool
 	Host    *Player
+
+	// The seed used to shuffle the deck. Two games started with the same seed are dealt exactly the same
+	// hands, which is useful to reproduce a game. A zero seed means a new seed is picked when dealing.
+	Seed int64
+
+	// The trump suit selected for the game or cards.NoSuit when playing without trumps
+	Trump cards.Suit
+
+	// The game being played. Refer to src/variants/variant.go for more info
+	Variant variants.Variant
+}
+
+// Returns the player seated at the given direction, or nil if the seat is empty
+func (c *GameContext) PlayerAt(direction int) *Player {
+	for player := range c.Players {
+		if player.Direction == direction {
+			return player
+		}
+	}
+	return nil
+}
+
+// Shuffles the deck with the context's seed and deals the whole deck evenly to every player in the game.
+// Cards are dealt one at a time starting from North and moving in directions.Order.
+func (c *GameContext) Deal(deck *cards.Deck) error {
+	if len(c.Players) == 0 {
+		return errors.New("deal error: no players in game")
+	}
+	return c.DealHands(deck, deck.Len()/len(c.Players))
+}
+
+// Same as Deal but only deals size cards to every player, leaving the rest of the deck undealt
+func (c *GameContext) DealHands(deck *cards.Deck, size int) error {
+	seated := make([]*Player, 0, len(c.Players))
+	for _, direction := range directions.Order {
+		if player := c.PlayerAt(direction); player != nil {
+			seated = append(seated, player)
+		}
+	}
+	if len(seated) != len(c.Players) {
+		return errors.New(fmt.Sprintf("deal error: %d players do not have a unique seat", len(c.Players)-len(seated)))
+	}
+
+	if c.Seed == 0 {
+		c.Seed = cards.NewSeed()
+	}
+	deck.Shuffle(c.Seed)
+
+	hands, err := deck.Deal(len(seated), size)
+	if err != nil {
+		return err
+	}
+	for i, player := range seated {
+		player.Cards = hands[i]
+	}
+	return nil
 }
@@ -1,8 +1,20 @@
 package interfaces
 
+import "CardGameGo/src/cards"
+
 type Player struct {
 	Name      string
 	Id        string
 	IsHost    bool
 	Direction int
+
+	// Reports whether the player is ready for the game to start. Refer to the lobby screen
+	Ready bool
+
+	// Reports whether the player lost its connection during the game. Its seat is kept until it comes back
+	// with the same Id
+	Away bool
+
+	// The cards currently held by the player
+	Cards []cards.Card
 }
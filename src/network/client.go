@@ -0,0 +1,299 @@
+package network
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/protocol"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// The time a client waits for the host to answer its join request
+const joinTimeout = 5 * time.Second
+
+// The time a client keeps trying to get its seat back after losing the connection to the host
+const reconnectTimeout = 2 * time.Minute
+
+// The time between two attempts to reconnect to the host
+const reconnectInterval = 2 * time.Second
+
+// A device playing at a table hosted by another device. When the connection to the host is lost the client
+// reconnects on its own and gets its seat back, refer to Connected.
+type Client struct {
+	// The seat given by the host and the game played at its table
+	Seat   int
+	GameId string
+
+	// Identifies the player to the host so that it gets its seat back after reconnecting
+	Id string
+
+	// Reports whether the client only watches the game. Refer to Watch
+	Spectator bool
+
+	address string
+	name    string
+
+	// Protects everything below, which is written by the reading goroutine
+	mutex     sync.Mutex
+	conn      net.Conn
+	connected bool
+	closed    bool
+	state     *protocol.State
+	updated   bool
+	events    []protocol.Message
+	err       error
+
+	// Closed by Close, interrupting the wait between two attempts to reconnect
+	done chan struct{}
+}
+
+// Connects to the host at the given address and asks for a seat at its table. The id identifies the player
+// when it reconnects, a new one is picked when it is empty. Refer to NewPlayerId
+func Dial(address, name, id string) (*Client, error) {
+	if id == "" {
+		id = NewPlayerId()
+	}
+
+	client := &Client{Id: id, address: address, name: name, done: make(chan struct{})}
+	conn, welcome, err := client.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	client.Seat = welcome.Seat
+	client.GameId = welcome.GameId
+	client.conn = conn
+	client.connected = true
+	go client.read(conn)
+	return client, nil
+}
+
+// Connects to the host at the given address to watch its game without a seat. Spectators see the table but
+// none of the hands, unless the host opened them, and every request to play is refused.
+func Watch(address, name string) (*Client, error) {
+	client := &Client{Seat: protocol.Spectator, Id: NewPlayerId(), Spectator: true, address: address, name: name,
+		done: make(chan struct{})}
+	conn, welcome, err := client.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	client.GameId = welcome.GameId
+	client.conn = conn
+	client.connected = true
+	go client.read(conn)
+	return client, nil
+}
+
+// Opens a connection to the host and asks for a seat
+func (c *Client) connect() (net.Conn, *protocol.Welcome, error) {
+	conn, err := net.DialTimeout("tcp", c.address, joinTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = protocol.Write(conn, &protocol.Join{Name: c.name, Id: c.Id, Spectate: c.Spectator})
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, err
+	}
+
+	// The answer is read before handing the connection over to the reading goroutine
+	_ = conn.SetReadDeadline(time.Now().Add(joinTimeout))
+	answer, err := protocol.Read(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, errors.New(fmt.Sprintf("join error: %q", err))
+	}
+	welcome, ok := answer.(*protocol.Welcome)
+	if !ok {
+		_ = conn.Close()
+		if rejected, ok := answer.(*protocol.Error); ok {
+			return nil, nil, rejected
+		}
+		return nil, nil, errors.New("join error: unexpected answer from the host")
+	}
+	_ = conn.SetReadDeadline(time.Time{})
+	return conn, welcome, nil
+}
+
+func (c *Client) read(conn net.Conn) {
+	for {
+		message, err := protocol.Read(conn)
+		if protocol.Recoverable(err) {
+			fmt.Printf("ignoring message from the host: %q\n", err)
+			continue
+		}
+		if err != nil {
+			conn = c.reconnect(err)
+			if conn == nil {
+				return
+			}
+			continue
+		}
+
+		c.mutex.Lock()
+		if state, ok := message.(*protocol.State); ok {
+			c.state = state
+			c.updated = true
+		} else {
+			c.events = append(c.events, message)
+		}
+		c.mutex.Unlock()
+	}
+}
+
+// Tries to get the seat back after the connection was lost, until reconnectTimeout. Returns the new
+// connection, or nil if the client was closed or gave up. Closing the client stops the attempts right away,
+// even while waiting for the next one.
+func (c *Client) reconnect(cause error) net.Conn {
+	c.mutex.Lock()
+	c.connected = false
+	c.mutex.Unlock()
+
+	deadline := time.Now().Add(reconnectTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-c.done:
+			return nil
+		case <-time.After(reconnectInterval):
+		}
+		if c.isClosed() {
+			return nil
+		}
+
+		conn, _, err := c.connect()
+		if err != nil {
+			fmt.Printf("ignoring reconnect error %q\n", err)
+			continue
+		}
+
+		c.mutex.Lock()
+		if c.closed {
+			c.mutex.Unlock()
+			_ = conn.Close()
+			return nil
+		}
+		c.conn = conn
+		c.connected = true
+		c.mutex.Unlock()
+		return conn
+	}
+
+	c.mutex.Lock()
+	c.err = errors.New(fmt.Sprintf("connection error: %q", cause))
+	c.mutex.Unlock()
+	return nil
+}
+
+// Reports whether the client was closed. Refer to Close
+func (c *Client) isClosed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.closed
+}
+
+// Reports whether the client is connected to the host. It is not while reconnecting after the connection was
+// lost, in which case every request fails until the seat is back
+func (c *Client) Connected() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.connected
+}
+
+func (c *Client) send(message protocol.Message) error {
+	c.mutex.Lock()
+	conn, connected := c.conn, c.connected
+	c.mutex.Unlock()
+	if !connected {
+		return errors.New("connection error: reconnecting to the host")
+	}
+	if c.Spectator && message.Type() != protocol.RenameType && message.Type() != protocol.ChatType {
+		return errors.New("request error: spectators cannot play")
+	}
+	return protocol.Write(conn, message)
+}
+
+// Asks the host to move the client to a free seat. Refer to Poll for the seat actually given
+func (c *Client) TakeSeat(seat int) error {
+	return c.send(&protocol.TakeSeat{Seat: seat})
+}
+
+// Tells the host whether the client is ready for the game to start
+func (c *Client) Ready(ready bool) error {
+	return c.send(&protocol.Ready{Ready: ready})
+}
+
+// Changes the name the other players see
+func (c *Client) Rename(name string) error {
+	return c.send(&protocol.Rename{Name: name})
+}
+
+// Sends a line of chat to every player through the host
+func (c *Client) Say(text string) error {
+	return c.send(&protocol.Chat{Seat: c.Seat, Text: text})
+}
+
+// Sends a quick reaction, one of protocol.Reactions, to every player through the host
+func (c *Client) React(reaction string) error {
+	return c.send(&protocol.Reaction{Seat: c.Seat, Reaction: reaction})
+}
+
+// Asks the host to record a bid for the client's seat
+func (c *Client) Bid(bid int) error {
+	return c.send(&protocol.Bid{Seat: c.Seat, Bid: bid})
+}
+
+// Asks the host to play a card for the client's seat
+func (c *Client) Play(card cards.Card) error {
+	return c.send(&protocol.PlayCard{Seat: c.Seat, Card: card})
+}
+
+// Asks the host to collect the complete trick
+func (c *Client) Claim() error {
+	return c.send(&protocol.Claim{})
+}
+
+// Returns the latest state received from the host, or nil if nothing changed since the last call, along with
+// every other message received in the meantime (deals, trick results, scores, rejected requests...). Seat
+// is updated when the host moved the client to another seat.
+func (c *Client) Poll() (*protocol.State, []protocol.Message) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var state *protocol.State
+	if c.updated {
+		state = c.state
+	}
+	events := c.events
+	c.updated, c.events = false, nil
+
+	for _, event := range events {
+		if lobby, ok := event.(*protocol.Lobby); ok {
+			c.Seat = lobby.Seat
+		}
+	}
+	return state, events
+}
+
+// Returns the reason the connection to the host was lost for good, or nil while it is still open or the
+// client is reconnecting
+func (c *Client) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+// Leaves the table
+func (c *Client) Close() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.closed {
+		close(c.done)
+	}
+	c.closed = true
+	c.connected = false
+	return c.conn.Close()
+}
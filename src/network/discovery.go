@@ -0,0 +1,203 @@
+package network
+
+import (
+	"CardGameGo/src/protocol"
+	"encoding/json"
+	"math/rand"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The UDP port hosts advertise their games on
+const DiscoveryPort = 7778
+
+// The time between two advertisements of the same game
+const advertiseInterval = time.Second
+
+// The time after which a game that stopped advertising is dropped from the list of discovered games
+const discoveryTimeout = 3 * advertiseInterval
+
+// What a host tells the local network about its game
+type Advertisement struct {
+	GameId  string `json:"gameId"`
+	Host    string `json:"host"`
+	Variant string `json:"variant"`
+
+	// The number of seats a client may still take
+	FreeSeats int `json:"freeSeats"`
+
+	// The TCP port the host accepts clients on
+	Port int `json:"port"`
+
+	// The version of the protocol spoken by the host. Refer to protocol.Version
+	Version int `json:"version"`
+}
+
+// A game discovered on the local network
+type DiscoveredGame struct {
+	Advertisement
+
+	// The address to pass to Dial to join the game
+	Address string
+
+	lastSeen time.Time
+}
+
+// Reports whether this version of the application can join the game
+func (g DiscoveredGame) Compatible() bool {
+	return g.Version >= protocol.MinVersion
+}
+
+// Returns a new random game id, used to tell apart the games advertised on the same network
+func NewGameId() string {
+	return strconv.FormatUint(rand.Uint64(), 36)
+}
+
+// Returns a new random player id, used by the host to recognise a player that reconnects. Refer to Dial
+func NewPlayerId() string {
+	return strconv.FormatUint(rand.Uint64(), 36)
+}
+
+// Regularly broadcasts an advertisement on the local network until stopped
+type Advertiser struct {
+	// Where the advertisements are sent, the broadcast address of the local network by default
+	Target *net.UDPAddr
+
+	conn *net.UDPConn
+	stop chan struct{}
+
+	mutex         sync.Mutex
+	advertisement Advertisement
+}
+
+// Provided constructor. Nothing is sent until Start is called.
+func NewAdvertiser(advertisement Advertisement) *Advertiser {
+	return &Advertiser{
+		Target:        &net.UDPAddr{IP: net.IPv4bcast, Port: DiscoveryPort},
+		advertisement: advertisement,
+	}
+}
+
+// Changes what is advertised, for example when a seat is taken. Safe to call from any goroutine.
+func (a *Advertiser) Set(advertisement Advertisement) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.advertisement = advertisement
+}
+
+func (a *Advertiser) Start() error {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return err
+	}
+	a.conn = conn
+	a.stop = make(chan struct{})
+
+	go a.run(conn, a.stop)
+	return nil
+}
+
+func (a *Advertiser) run(conn *net.UDPConn, stop chan struct{}) {
+	ticker := time.NewTicker(advertiseInterval)
+	defer ticker.Stop()
+
+	for {
+		a.mutex.Lock()
+		data, err := json.Marshal(a.advertisement)
+		a.mutex.Unlock()
+		if err == nil {
+			_, _ = conn.WriteToUDP(data, a.Target)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+func (a *Advertiser) Stop() error {
+	if a.conn == nil {
+		return nil
+	}
+	close(a.stop)
+	err := a.conn.Close()
+	a.conn = nil
+	return err
+}
+
+// Listens to the advertisements of the local network and keeps the list of the games currently advertised
+type Browser struct {
+	conn *net.UDPConn
+
+	mutex sync.Mutex
+	games map[string]*DiscoveredGame
+}
+
+// Starts listening to the advertisements sent to the given port, usually DiscoveryPort
+func NewBrowser(port int) (*Browser, error) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{Port: port})
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Browser{conn: conn, games: make(map[string]*DiscoveredGame)}
+	go b.listen()
+	return b, nil
+}
+
+func (b *Browser) listen() {
+	buffer := make([]byte, 2048)
+	for {
+		n, sender, err := b.conn.ReadFromUDP(buffer)
+		if err != nil {
+			return
+		}
+
+		game := &DiscoveredGame{}
+		if json.Unmarshal(buffer[:n], &game.Advertisement) != nil || game.GameId == "" {
+			continue
+		}
+		game.Address = net.JoinHostPort(sender.IP.String(), strconv.Itoa(game.Port))
+		game.lastSeen = time.Now()
+
+		b.mutex.Lock()
+		b.games[game.Address] = game
+		b.mutex.Unlock()
+	}
+}
+
+// Returns the games advertised recently, sorted by host name
+func (b *Browser) Games() []DiscoveredGame {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	games := make([]DiscoveredGame, 0, len(b.games))
+	for address, game := range b.games {
+		if time.Since(game.lastSeen) > discoveryTimeout {
+			delete(b.games, address)
+			continue
+		}
+		games = append(games, *game)
+	}
+	sort.Slice(games, func(i, j int) bool {
+		if games[i].Host != games[j].Host {
+			return games[i].Host < games[j].Host
+		}
+		return games[i].Address < games[j].Address
+	})
+	return games
+}
+
+// Returns the address the browser listens on
+func (b *Browser) Addr() net.Addr {
+	return b.conn.LocalAddr()
+}
+
+func (b *Browser) Close() error {
+	return b.conn.Close()
+}
@@ -0,0 +1,14 @@
+// Lets several devices play at the same table. The device hosting the game runs a Server that owns the only
+// real game.Match: it is the single authority on the cards, every action of the other devices is sent to it
+// and validated by the rules engine before being applied. The other devices join as a Client and only ever
+// receive the part of the game their seat is allowed to see, refer to protocol.State for more info.
+//
+// The server never touches the match from its network goroutines. Requests are queued and only applied when
+// the host calls Server.Process from its main loop, the same goroutine that draws the match.
+//
+// The messages and their encoding are defined in src/protocol. Like the rules engine, this package must
+// never import SDL so that it can be tested with loopback connections.
+package network
+
+// The TCP port a host listens on unless told otherwise
+const DefaultPort = 7777
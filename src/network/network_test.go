@@ -0,0 +1,752 @@
+package network
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"CardGameGo/src/protocol"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"net"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Starts a server on a loopback port hosting a Court Piece match dealt from a fixed seed, East being the host
+func newTestServer(t *testing.T) *Server {
+	match := game.NewMatch(variants.CourtPiece{}, directions.East, 42)
+	err := match.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := NewServer("test", match, directions.East, "host")
+	err = server.Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = server.Close()
+	})
+	return server
+}
+
+// Dials the server while processing its requests, as the host's main loop would
+func dial(t *testing.T, server *Server, name, id string) (*Client, error) {
+	type result struct {
+		client *Client
+		err    error
+	}
+	joined := make(chan result)
+	go func() {
+		client, err := Dial(server.Addr().String(), name, id)
+		joined <- result{client, err}
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		server.Process()
+		select {
+		case r := <-joined:
+			return r.client, r.err
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+	t.Fatal("timed out joining the server")
+	return nil, nil
+}
+
+func join(t *testing.T, server *Server, name string) *Client {
+	client, err := dial(t, server, name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+	return client
+}
+
+// Returns the requests rejected by the host among the messages received by a client
+func rejections(events []protocol.Message) []error {
+	var rejected []error
+	for _, event := range events {
+		if err, ok := event.(*protocol.Error); ok {
+			rejected = append(rejected, err)
+		}
+	}
+	return rejected
+}
+
+// Processes the server's requests until the client receives a state satisfying the condition
+func waitState(t *testing.T, server *Server, client *Client,
+	condition func(*protocol.State) bool) (*protocol.State, []error) {
+
+	var rejected []error
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		server.Process()
+		state, events := client.Poll()
+		rejected = append(rejected, rejections(events)...)
+		if state != nil && condition(state) {
+			return state, rejected
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the state")
+	return nil, nil
+}
+
+func TestJoinSeatsClientsAroundTheHost(t *testing.T) {
+	server := newTestServer(t)
+
+	seats := make(map[int]bool)
+	for _, name := range []string{"a", "b", "c"} {
+		client := join(t, server, name)
+		if client.Seat == directions.East || seats[client.Seat] {
+			t.Fatalf("%s was given the seat %s", name, directions.String(client.Seat))
+		}
+		seats[client.Seat] = true
+	}
+
+	_, err := dial(t, server, "d", "")
+	if err == nil {
+		t.Fatal("a fifth player joined a full table")
+	}
+}
+
+func TestStateOnlyHoldsTheSeatsCards(t *testing.T) {
+	server := newTestServer(t)
+	client := join(t, server, "a")
+
+	state, _ := waitState(t, server, client, func(s *protocol.State) bool { return true })
+	if state.Seat != client.Seat {
+		t.Fatalf("state of %s sent to %s", directions.String(state.Seat), directions.String(client.Seat))
+	}
+	if len(state.Hand) != 13 {
+		t.Fatalf("expected 13 cards, got %d", len(state.Hand))
+	}
+	for i, card := range state.Hand {
+		if card != server.Match.Hand.Hand(client.Seat)[i] {
+			t.Fatalf("the state holds %s instead of %s", card, server.Match.Hand.Hand(client.Seat)[i])
+		}
+	}
+}
+
+func TestClientsPlayTheirTurns(t *testing.T) {
+	server := newTestServer(t)
+	clients := make(map[int]*Client)
+	for _, name := range []string{"a", "b", "c"} {
+		client := join(t, server, name)
+		clients[client.Seat] = client
+	}
+
+	// Play a whole trick, the host playing its own seat directly on the match like the game ui does
+	g := server.Match.Hand
+	for i := 0; i < len(directions.Order); i++ {
+		seat := g.Current()
+		card := g.LegalMoves(seat)[0]
+		if seat == server.HostSeat {
+			err := g.Play(seat, card)
+			if err != nil {
+				t.Fatal(err)
+			}
+			server.Broadcast()
+			continue
+		}
+
+		played := len(g.Table.Trick.Plays)
+		err := clients[seat].Play(card)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, rejected := waitState(t, server, clients[seat], func(s *protocol.State) bool {
+			return len(s.Trick.Plays) == played+1
+		})
+		if len(rejected) > 0 {
+			t.Fatal(rejected[0])
+		}
+	}
+
+	if !g.Table.TrickComplete() {
+		t.Fatal("the trick is not complete")
+	}
+	client := clients[directions.Next(server.HostSeat)]
+	err := client.Claim()
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitState(t, server, client, func(s *protocol.State) bool { return len(s.Tricks) == 1 })
+}
+
+func TestIllegalPlaysAreRejected(t *testing.T) {
+	server := newTestServer(t)
+	client := join(t, server, "a")
+
+	// A card the seat does not hold
+	card := server.Match.Hand.Hand(directions.Partner(client.Seat))[0]
+	err := client.Play(card)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		server.Process()
+		if _, events := client.Poll(); len(rejections(events)) > 0 {
+			if len(server.Match.Hand.Hand(client.Seat)) != 13 {
+				t.Fatal("the illegal play was applied")
+			}
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("the illegal play was not rejected")
+}
+
+func TestIllegalClaimsAreRejected(t *testing.T) {
+	server := newTestServer(t)
+	client := join(t, server, "a")
+	g := server.Match.Hand
+
+	// The trick is not complete yet
+	err := client.Claim()
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitRejection(t, server, client)
+
+	// The host plays tricks for every seat until one is won by another seat than the client's
+	for {
+		for !g.Table.TrickComplete() {
+			current := g.Current()
+			err = g.Play(current, g.LegalMoves(current)[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if g.Table.Trick.Winner(g.Trump).Seat != client.Seat {
+			break
+		}
+		_, err = g.Collect()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	server.Broadcast()
+	collected := len(g.Table.Tricks)
+
+	err = client.Claim()
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitRejection(t, server, client)
+	if len(g.Table.Tricks) != collected || !g.Table.TrickComplete() {
+		t.Fatal("the trick won by another seat was collected")
+	}
+}
+
+// Processes the server's requests until the client receives a lobby satisfying the condition
+func waitLobby(t *testing.T, server *Server, client *Client,
+	condition func(*protocol.Lobby) bool) (*protocol.Lobby, []error) {
+
+	var rejected []error
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		server.Process()
+		_, events := client.Poll()
+		rejected = append(rejected, rejections(events)...)
+		for _, event := range events {
+			if lobby, ok := event.(*protocol.Lobby); ok && condition(lobby) {
+				return lobby, rejected
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the lobby")
+	return nil, nil
+}
+
+// Processes the server's requests until a request of the client is rejected
+func waitRejection(t *testing.T, server *Server, client *Client) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		server.Process()
+		if _, events := client.Poll(); len(rejections(events)) > 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("the request was not rejected")
+}
+
+func TestLobby(t *testing.T) {
+	server := newTestServer(t)
+	server.Started = false
+	client := join(t, server, "a")
+	other := join(t, server, "b")
+
+	// A free seat can be taken, a taken one cannot
+	err := client.TakeSeat(directions.West)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitLobby(t, server, client, func(l *protocol.Lobby) bool { return l.Seat == directions.West })
+	if client.Seat != directions.West {
+		t.Fatalf("the client sits at %s", directions.String(client.Seat))
+	}
+	err = other.TakeSeat(directions.West)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitRejection(t, server, other)
+	if other.Seat == directions.West {
+		t.Fatal("a taken seat was given away")
+	}
+
+	// Names and ready states are shown to everyone
+	err = client.Rename("a very long name that does not fit")
+	if err == nil {
+		err = client.Ready(true)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitLobby(t, server, other, func(l *protocol.Lobby) bool {
+		seat := l.Seats[directions.West]
+		return seat.Ready && seat.Name == "a very long name tha"
+	})
+	if server.AllReady() {
+		t.Fatal("every client is ready although one is not")
+	}
+
+	// The host takes the seat of the client, which gets the seat of the host and has to be ready again
+	err = server.MoveHost(directions.West)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Broadcast()
+	lobby, _ := waitLobby(t, server, client, func(l *protocol.Lobby) bool { return l.Seat == directions.East })
+	if !lobby.Seats[directions.West].Host || lobby.Seats[directions.East].Ready {
+		t.Fatalf("unexpected lobby after the swap %+v", lobby.Seats)
+	}
+
+	// Seats cannot change once the game started
+	server.Started = true
+	err = client.TakeSeat(directions.South)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitRejection(t, server, client)
+}
+
+// Processes the server's requests until the condition holds
+func waitServer(t *testing.T, server *Server, condition func() bool) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		server.Process()
+		if condition() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for the server")
+}
+
+func TestSeatIsKeptForAbsentPlayers(t *testing.T) {
+	server := newTestServer(t)
+	server.Started = true
+
+	client, err := dial(t, server, "a", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seat := client.Seat
+	g := server.Match.Hand
+	if seat == g.Current() {
+		t.Fatal("the test expects another seat to play first")
+	}
+
+	// The table moves on while the client is away
+	err = client.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitServer(t, server, func() bool { return server.Away(seat) })
+	err = g.Play(g.Current(), g.LegalMoves(g.Current())[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Broadcast()
+
+	// Nobody else may take the seat
+	for _, name := range []string{"b", "c"} {
+		other := join(t, server, name)
+		if other.Seat == seat {
+			t.Fatalf("%s was given the seat of the absent player", name)
+		}
+	}
+	if _, err = dial(t, server, "d", ""); err == nil {
+		t.Fatal("a new player took the seat of the absent player")
+	}
+
+	// The client gets its seat back along with its cards and the current trick
+	back, err := dial(t, server, "a", "alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer back.Close()
+	if back.Seat != seat || server.Away(seat) {
+		t.Fatalf("the client came back at %s instead of %s", directions.String(back.Seat), directions.String(seat))
+	}
+	state, _ := waitState(t, server, back, func(*protocol.State) bool { return true })
+	rebuilt, err := state.Match()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rebuilt.Hand.Hand(seat), g.Hand(seat)) {
+		t.Fatal("the client did not get its cards back")
+	}
+	if !reflect.DeepEqual(rebuilt.Hand.Table.Trick, g.Table.Trick) || rebuilt.Hand.Current() != g.Current() {
+		t.Fatal("the client did not get the current trick")
+	}
+}
+
+func TestClientsReconnectOnTheirOwn(t *testing.T) {
+	server := newTestServer(t)
+	server.Started = true
+	client := join(t, server, "a")
+	seat := client.Seat
+
+	// The host drops the connection, the client comes back to the same seat
+	server.seats[seat].close()
+	waitServer(t, server, func() bool { return server.Away(seat) })
+	waitServer(t, server, func() bool { return server.Seated(seat) && client.Connected() })
+	if client.Err() != nil {
+		t.Fatal(client.Err())
+	}
+
+	err := client.Claim()
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitRejection(t, server, client)
+}
+
+func TestSpectators(t *testing.T) {
+	server := newTestServer(t)
+	server.Started = true
+
+	type result struct {
+		client *Client
+		err    error
+	}
+	watching := make(chan result)
+	go func() {
+		client, err := Watch(server.Addr().String(), "watcher")
+		watching <- result{client, err}
+	}()
+	var r result
+	waitServer(t, server, func() bool {
+		select {
+		case r = <-watching:
+			return true
+		default:
+			return false
+		}
+	})
+	if r.err != nil {
+		t.Fatal(r.err)
+	}
+	spectator := r.client
+	defer spectator.Close()
+
+	// Spectators do not take a seat
+	for _, name := range []string{"a", "b", "c"} {
+		join(t, server, name)
+	}
+	if server.Spectators() != 1 || spectator.Seat != protocol.Spectator {
+		t.Fatal("the spectator was not let in as a spectator")
+	}
+
+	// The hands are hidden until the host opens them
+	state, _ := waitState(t, server, spectator, func(s *protocol.State) bool { return true })
+	if len(state.Hand) != 0 || len(state.Hands) != 0 {
+		t.Fatal("a spectator was sent hidden cards")
+	}
+	server.OpenHands = true
+	server.Broadcast()
+	state, _ = waitState(t, server, spectator, func(s *protocol.State) bool { return len(s.Hands) > 0 })
+	for _, seat := range directions.Order {
+		if !reflect.DeepEqual(state.Hands[seat], server.Match.Hand.Hand(seat)) {
+			t.Fatalf("the open hand of %s differs", directions.String(seat))
+		}
+	}
+
+	if spectator.Play(server.Match.Hand.Hand(server.Match.Hand.Current())[0]) == nil {
+		t.Fatal("a spectator was allowed to play")
+	}
+}
+
+// Processes the server's requests until the client receives a message of the given kind
+func waitMessage(t *testing.T, server *Server, client *Client, kind string) (protocol.Message, []error) {
+	var rejected []error
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		server.Process()
+		_, events := client.Poll()
+		rejected = append(rejected, rejections(events)...)
+		for _, event := range events {
+			if event.Type() == kind {
+				return event, rejected
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for a %s message", kind)
+	return nil, nil
+}
+
+func TestChatAndReactions(t *testing.T) {
+	server := newTestServer(t)
+	client := join(t, server, "a")
+	other := join(t, server, "b")
+
+	err := client.Say("  good luck  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, _ := waitMessage(t, server, other, protocol.ChatType)
+	expected := &protocol.Chat{Seat: client.Seat, Name: "a", Text: "good luck"}
+	if !reflect.DeepEqual(message, expected) {
+		t.Fatalf("received %+v, expected %+v", message, expected)
+	}
+	if messages := server.Messages(); len(messages) != 1 || !reflect.DeepEqual(messages[0], expected) {
+		t.Fatalf("the host got %+v", messages)
+	}
+
+	// Only the known reactions are sent on
+	err = client.React("boo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitRejection(t, server, client)
+	err = client.React(protocol.Reactions[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, _ = waitMessage(t, server, other, protocol.ReactionType)
+	if reaction := message.(*protocol.Reaction); reaction.Seat != client.Seat {
+		t.Fatalf("the reaction of %s came from %s", directions.String(client.Seat), directions.String(reaction.Seat))
+	}
+
+	// The host chats too
+	err = server.Say("welcome")
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, _ = waitMessage(t, server, client, protocol.ChatType)
+	if chat := message.(*protocol.Chat); chat.Seat != server.HostSeat || chat.Text != "welcome" {
+		t.Fatalf("received %+v", chat)
+	}
+}
+
+// A connection to the server that records every message the host sends to it, as a client would receive
+// them before any processing
+type recorder struct {
+	conn     net.Conn
+	mutex    sync.Mutex
+	messages []protocol.Message
+}
+
+// Joins the server, or watches the game when spectate is set, and records everything the host sends
+func record(t *testing.T, server *Server, name string, spectate bool) *recorder {
+	conn, err := net.Dial("tcp", server.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	r := &recorder{conn: conn}
+	go func() {
+		for {
+			message, err := protocol.Read(conn)
+			if protocol.Recoverable(err) {
+				continue
+			}
+			if err != nil {
+				return
+			}
+			r.mutex.Lock()
+			r.messages = append(r.messages, message)
+			r.mutex.Unlock()
+		}
+	}()
+
+	err = protocol.Write(conn, &protocol.Join{Name: name, Spectate: spectate})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitServer(t, server, func() bool { return r.seat() != nil })
+	return r
+}
+
+// Returns the seat given to the recorder, or nil until it is welcomed
+func (r *recorder) seat() *int {
+	for _, message := range r.received() {
+		if welcome, ok := message.(*protocol.Welcome); ok {
+			return &welcome.Seat
+		}
+	}
+	return nil
+}
+
+func (r *recorder) received() []protocol.Message {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]protocol.Message(nil), r.messages...)
+}
+
+// Returns the cards of a message that are not public, that is the cards held in a hand as opposed to the
+// cards played
+func hiddenCards(message protocol.Message) []cards.Card {
+	switch m := message.(type) {
+	case *protocol.Deal:
+		return m.Hand
+	case *protocol.State:
+		hidden := append([]cards.Card(nil), m.Hand...)
+		for _, hand := range m.Hands {
+			hidden = append(hidden, hand...)
+		}
+		return hidden
+	default:
+		return nil
+	}
+}
+
+func TestNoMessageHoldsAnotherSeatsCards(t *testing.T) {
+	server := newTestServer(t)
+	server.Started = true
+	dealt := server.Match.Hand.Dealt
+
+	var players []*recorder
+	for _, name := range []string{"a", "b", "c"} {
+		players = append(players, record(t, server, name, false))
+	}
+	spectator := record(t, server, "watcher", true)
+
+	// The host plays the whole hand for every seat, telling the clients after every action
+	g := server.Match.Hand
+	for g.Phase == game.Playing {
+		if g.Table.TrickComplete() {
+			_, err := g.Collect()
+			if err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			seat := g.Current()
+			err := g.Play(seat, g.LegalMoves(seat)[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		server.Broadcast()
+	}
+	_, err := server.Match.EndHand()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Broadcast()
+
+	// Wait for the last state of the hand to reach everyone
+	over := func(r *recorder) bool {
+		messages := r.received()
+		state, ok := messages[len(messages)-1].(*protocol.State)
+		return ok && state.Phase == game.HandOver
+	}
+	waitServer(t, server, func() bool {
+		for _, r := range append(players, spectator) {
+			if !over(r) {
+				return false
+			}
+		}
+		return true
+	})
+
+	for _, r := range players {
+		seat := *r.seat()
+		for _, message := range r.received() {
+			for _, card := range hiddenCards(message) {
+				if !cards.Contains(dealt[seat], card) {
+					t.Fatalf("%s was sent %s of another seat in a %s message", directions.String(seat), card,
+						message.Type())
+				}
+			}
+		}
+	}
+	for _, message := range spectator.received() {
+		if len(hiddenCards(message)) > 0 {
+			t.Fatalf("the spectator was sent hidden cards in a %s message", message.Type())
+		}
+	}
+}
+
+func TestActionsForAnotherSeatAreRejected(t *testing.T) {
+	server := newTestServer(t)
+	cheater := record(t, server, "cheater", false)
+	seat := *cheater.seat()
+
+	// Play the legal card of the seat whose turn it is, pretending to sit there
+	g := server.Match.Hand
+	current := g.Current()
+	if current == seat {
+		t.Fatal("the test expects another seat to play first")
+	}
+	err := protocol.Write(cheater.conn, &protocol.PlayCard{Seat: current, Card: g.LegalMoves(current)[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitServer(t, server, func() bool { return len(rejections(cheater.received())) > 0 })
+	if len(g.Table.Trick.Plays) != 0 {
+		t.Fatal("the play for another seat was applied")
+	}
+}
+
+func TestDiscovery(t *testing.T) {
+	browser, err := NewBrowser(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer browser.Close()
+
+	advertisement := Advertisement{GameId: NewGameId(), Host: "host", Variant: "Hearts", FreeSeats: 3, Port: 1234,
+		Version: protocol.Version}
+	advertiser := NewAdvertiser(advertisement)
+	advertiser.Target = browser.Addr().(*net.UDPAddr)
+	advertiser.Target.IP = net.IPv4(127, 0, 0, 1)
+	err = advertiser.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer advertiser.Stop()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		games := browser.Games()
+		if len(games) == 1 {
+			if games[0].Advertisement != advertisement || games[0].Address != "127.0.0.1:1234" {
+				t.Fatalf("discovered %+v", games[0])
+			}
+			if !games[0].Compatible() {
+				t.Fatal("the game is not compatible")
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("the game was not discovered")
+}
@@ -0,0 +1,735 @@
+package network
+
+import (
+	"CardGameGo/src/game"
+	"CardGameGo/src/protocol"
+	"CardGameGo/src/utils/directions"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// The number of messages waiting to be sent to a client before the client is considered too slow and is
+// disconnected. The snapshots of the table do not count, only the latest one is kept, refer to peer
+const outgoingQueue = 64
+
+// The number of requests waiting to be processed by the host before the clients stop being read
+const requestQueue = 256
+
+// The longest name a player may use, in bytes. Longer names are cut.
+const maxNameLength = 20
+
+// The largest number of spectators watching the same game
+const maxSpectators = 8
+
+// A message received from a client. A nil message means the client left.
+type request struct {
+	peer    *peer
+	message protocol.Message
+}
+
+// A seat kept for a client that lost its connection during the game
+type absence struct {
+	id   string
+	name string
+}
+
+// A connection to a single client
+type peer struct {
+	conn net.Conn
+
+	// The frames waiting to be sent, in order, followed by the latest snapshot of the table. A snapshot holds
+	// the whole table so it replaces the one not sent yet, which keeps a client that falls behind, for example
+	// during a short stall of its network, from piling up outdated states. Only accessed with mutex held
+	mutex     sync.Mutex
+	outgoing  [][]byte
+	snapshot  [][]byte
+	finishing bool
+
+	// Signals the writer that there is something to send
+	pending chan struct{}
+	done    chan struct{}
+
+	// The seat of the client, or -1 until it has joined. Only accessed from Server.Process
+	seat      int
+	name      string
+	id        string
+	ready     bool
+	spectator bool
+
+	closeOnce sync.Once
+}
+
+type Server struct {
+	GameId string
+
+	// The match being played. It must only be accessed from the goroutine calling Process
+	Match *game.Match
+
+	// The seat and the name of the player of the hosting device
+	HostSeat int
+	HostName string
+
+	// Reports whether the host started the game. Until then the clients may still move to another seat.
+	// It must only be accessed from the goroutine calling Process
+	Started bool
+
+	// Reports whether the spectators see every hand, for example to coach the players. It must only be
+	// accessed from the goroutine calling Process
+	OpenHands bool
+
+	listener   net.Listener
+	requests   chan request
+	advertiser *Advertiser
+
+	// The seated clients, keyed by direction. Only accessed from Process and Broadcast
+	seats map[int]*peer
+
+	// The seats kept for the clients that lost their connection during the game, keyed by direction
+	absent map[int]absence
+
+	// The clients watching the game without a seat
+	spectators map[*peer]bool
+
+	// The chat lines and reactions of the clients not yet returned by Messages
+	messages []protocol.Message
+
+	// What the clients were last told about, so that Broadcast only announces what changed since
+	lastHand   *game.Game
+	lastTricks int
+	lastScores int
+}
+
+// Provided constructor. The server does not accept connections until Listen is called.
+func NewServer(gameId string, match *game.Match, hostSeat int, hostName string) *Server {
+	return &Server{
+		GameId:   gameId,
+		Match:    match,
+		HostSeat: hostSeat,
+		HostName: hostName,
+		requests: make(chan request, requestQueue),
+		seats:    make(map[int]*peer),
+		absent:   make(map[int]absence),
+
+		spectators: make(map[*peer]bool),
+	}
+}
+
+// Starts accepting clients on the given address, for example ":7777". Pass "127.0.0.1:0" to listen on any
+// free loopback port. Refer to Addr for the address actually used.
+func (s *Server) Listen(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	go s.accept()
+	return nil
+}
+
+// Returns the address the server listens on, or nil if it is not listening
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// Starts advertising the game on the local network so that other devices can discover it. Refer to Browser
+// for more info
+func (s *Server) Advertise() error {
+	if s.advertiser != nil {
+		return nil
+	}
+	advertiser := NewAdvertiser(s.advertisement())
+	err := advertiser.Start()
+	if err != nil {
+		return err
+	}
+	s.advertiser = advertiser
+	return nil
+}
+
+func (s *Server) advertisement() Advertisement {
+	port := 0
+	if addr, ok := s.Addr().(*net.TCPAddr); ok {
+		port = addr.Port
+	}
+	return Advertisement{
+		GameId:    s.GameId,
+		Host:      s.HostName,
+		Variant:   s.Match.Variant.Name(),
+		FreeSeats: len(directions.Order) - 1 - len(s.seats) - len(s.absent),
+		Port:      port,
+		Version:   protocol.Version,
+	}
+}
+
+// Stops accepting clients and disconnects every seated client
+func (s *Server) Close() error {
+	if s.advertiser != nil {
+		_ = s.advertiser.Stop()
+		s.advertiser = nil
+	}
+	for seat, p := range s.seats {
+		p.close()
+		delete(s.seats, seat)
+	}
+	for p := range s.spectators {
+		p.close()
+		delete(s.spectators, p)
+	}
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Close()
+}
+
+func (s *Server) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		p := &peer{conn: conn, pending: make(chan struct{}, 1), done: make(chan struct{}), seat: -1}
+		go p.write()
+		go s.read(p)
+	}
+}
+
+// Forwards every message of the client to Process until the connection is closed. Messages this version
+// cannot decode are answered with an error and skipped.
+func (s *Server) read(p *peer) {
+	for {
+		message, err := protocol.Read(p.conn)
+		if protocol.Recoverable(err) {
+			p.send(&protocol.Error{Message: err.Error()})
+			continue
+		}
+		if err != nil {
+			break
+		}
+		s.requests <- request{peer: p, message: message}
+	}
+	s.requests <- request{peer: p}
+}
+
+// Sends the queued frames and then the snapshot whenever there are some, until the connection is closed
+func (p *peer) write() {
+	for {
+		select {
+		case <-p.pending:
+		case <-p.done:
+			return
+		}
+
+		p.mutex.Lock()
+		frames := append(p.outgoing, p.snapshot...)
+		finishing := p.finishing
+		p.outgoing, p.snapshot = nil, nil
+		p.mutex.Unlock()
+
+		for _, frame := range frames {
+			_, err := p.conn.Write(frame)
+			if err != nil {
+				p.close()
+				return
+			}
+		}
+		if finishing {
+			p.close()
+			return
+		}
+	}
+}
+
+// Queues a message to be sent to the client. The message is encoded right away since it usually shares data
+// with the match. A client that does not keep up is disconnected.
+func (p *peer) send(message protocol.Message) {
+	frame, err := protocol.Frame(message)
+	if err != nil {
+		fmt.Printf("ignoring message %s: %q\n", message.Type(), err)
+		return
+	}
+
+	p.mutex.Lock()
+	full := len(p.outgoing) >= outgoingQueue
+	if !full {
+		p.outgoing = append(p.outgoing, frame)
+	}
+	p.mutex.Unlock()
+
+	if full {
+		p.close()
+		return
+	}
+	p.signal()
+}
+
+// Replaces the snapshot of the table waiting to be sent, if any, by the given messages. They are sent after
+// every message queued so far
+func (p *peer) sendSnapshot(messages ...protocol.Message) {
+	frames := make([][]byte, 0, len(messages))
+	for _, message := range messages {
+		frame, err := protocol.Frame(message)
+		if err != nil {
+			fmt.Printf("ignoring message %s: %q\n", message.Type(), err)
+			return
+		}
+		frames = append(frames, frame)
+	}
+
+	p.mutex.Lock()
+	p.snapshot = frames
+	p.mutex.Unlock()
+	p.signal()
+}
+
+// Closes the connection once every message queued so far has been sent
+func (p *peer) closeAfterSending() {
+	p.mutex.Lock()
+	p.finishing = true
+	p.mutex.Unlock()
+	p.signal()
+}
+
+// Wakes the writer up, unless it is already due to wake up
+func (p *peer) signal() {
+	select {
+	case p.pending <- struct{}{}:
+	default:
+	}
+}
+
+func (p *peer) close() {
+	p.closeOnce.Do(func() {
+		_ = p.conn.Close()
+		close(p.done)
+	})
+}
+
+// Applies every request received from the clients since the last call and sends the new state to every
+// client. Must be called regularly from the goroutine that owns the match, typically once per frame. Reports
+// whether the match or the seats changed.
+func (s *Server) Process() bool {
+	changed := false
+	for {
+		select {
+		case r := <-s.requests:
+			err := s.handle(r)
+			if err != nil {
+				fmt.Printf("ignoring request from %s: %q\n", directions.String(r.peer.seat), err)
+				r.peer.send(&protocol.Error{Message: err.Error()})
+				continue
+			}
+			changed = true
+		default:
+			if changed {
+				s.Broadcast()
+			}
+			return changed
+		}
+	}
+}
+
+func (s *Server) handle(r request) error {
+	p := r.peer
+	if r.message == nil {
+		s.leave(p)
+		return nil
+	}
+
+	if join, ok := r.message.(*protocol.Join); ok {
+		if join.Spectate {
+			return s.watch(p, join.Name)
+		}
+		return s.join(p, join.Name, join.Id)
+	}
+	if chat, ok := r.message.(*protocol.Chat); ok && (p.seat >= 0 || p.spectator) {
+		return s.chat(p.seat, p.name, chat.Text)
+	}
+	if p.spectator {
+		if rename, ok := r.message.(*protocol.Rename); ok {
+			p.name = trimName(rename.Name)
+			return nil
+		}
+		return errors.New("request error: spectators cannot play")
+	}
+	if p.seat < 0 {
+		return errors.New("request error: join the table first")
+	}
+
+	// The game actions are checked against the rules before anything is applied, refer to validate
+	g := s.Match.Hand
+	err := validate(g, p.seat, r.message)
+	if err != nil {
+		return err
+	}
+
+	switch message := r.message.(type) {
+	case *protocol.TakeSeat:
+		return s.takeSeat(p, message.Seat)
+	case *protocol.Ready:
+		p.ready = message.Ready
+		return nil
+	case *protocol.Rename:
+		p.name = trimName(message.Name)
+		return nil
+	case *protocol.Reaction:
+		return s.react(p.seat, message.Reaction)
+	case *protocol.Bid:
+		return g.Bid(p.seat, message.Bid)
+	case *protocol.PlayCard:
+		return g.Play(p.seat, message.Card)
+	case *protocol.Claim:
+		_, err := g.Collect()
+		if err != nil {
+			return err
+		}
+		if g.Phase == game.HandOver {
+			_, err = s.Match.EndHand()
+		}
+		return err
+	default:
+		return errors.New(fmt.Sprintf("request error: unexpected message %q", message.Type()))
+	}
+}
+
+// Gives the client its seat back if it sat at the table before, or else the first free seat in
+// directions.Order
+func (s *Server) join(p *peer, name, id string) error {
+	if p.seat >= 0 || p.spectator {
+		return errors.New("join error: already seated")
+	}
+
+	if id != "" {
+		for seat, a := range s.absent {
+			if a.id == id {
+				delete(s.absent, seat)
+				s.seat(p, seat, name, id)
+				return nil
+			}
+		}
+
+		// The previous connection of the client may not be known to be lost yet
+		for seat, other := range s.seats {
+			if other.id == id {
+				other.seat = -1
+				other.close()
+				s.seat(p, seat, name, id)
+				return nil
+			}
+		}
+	}
+
+	for _, seat := range directions.Order {
+		if !s.free(seat) {
+			continue
+		}
+		s.seat(p, seat, name, id)
+		return nil
+	}
+
+	p.send(&protocol.Error{Message: "join error: the table is full"})
+	p.closeAfterSending()
+	return nil
+}
+
+// Lets the client watch the game without a seat
+func (s *Server) watch(p *peer, name string) error {
+	if p.seat >= 0 || p.spectator {
+		return errors.New("join error: already seated")
+	}
+	if len(s.spectators) >= maxSpectators {
+		p.send(&protocol.Error{Message: "join error: too many spectators"})
+		p.closeAfterSending()
+		return nil
+	}
+
+	p.spectator = true
+	p.name = trimName(name)
+	s.spectators[p] = true
+	p.send(&protocol.Welcome{GameId: s.GameId, Seat: protocol.Spectator})
+	s.sendSnapshot(p, s.Players(), s.Lobby())
+	return nil
+}
+
+// Seats the client and sends it the whole table right away, so that it can resume a game in progress
+func (s *Server) seat(p *peer, seat int, name, id string) {
+	p.seat = seat
+	p.name = trimName(name)
+	p.id = id
+	s.seats[seat] = p
+	p.send(&protocol.Welcome{GameId: s.GameId, Seat: seat})
+	s.sendSnapshot(p, s.Players(), s.Lobby())
+}
+
+// Frees the seat of a client that left. During the game the seat is kept for the client to come back,
+// refer to Away.
+func (s *Server) leave(p *peer) {
+	p.close()
+	if p.spectator {
+		delete(s.spectators, p)
+		return
+	}
+	if p.seat < 0 || s.seats[p.seat] != p {
+		return
+	}
+
+	delete(s.seats, p.seat)
+	if s.Started && p.id != "" {
+		s.absent[p.seat] = absence{id: p.id, name: p.name}
+		s.sendAll(&protocol.Leave{Seat: p.seat, Reason: "connection lost"})
+		return
+	}
+	s.sendAll(&protocol.Leave{Seat: p.seat})
+}
+
+// Reports whether a client may take the seat
+func (s *Server) free(seat int) bool {
+	_, away := s.absent[seat]
+	return seat != s.HostSeat && s.seats[seat] == nil && !away
+}
+
+// Moves a client to a free seat. Seats may only be changed before the game starts
+func (s *Server) takeSeat(p *peer, seat int) error {
+	if s.Started {
+		return errors.New("seat error: the game has already started")
+	}
+	if seat < 0 || seat >= len(directions.Order) {
+		return errors.New(fmt.Sprintf("seat error: unexpected seat %d", seat))
+	}
+	if seat == p.seat {
+		return nil
+	}
+	if !s.free(seat) {
+		return errors.New(fmt.Sprintf("seat error: %s is taken", directions.String(seat)))
+	}
+
+	delete(s.seats, p.seat)
+	p.seat = seat
+	p.ready = false
+	s.seats[seat] = p
+	return nil
+}
+
+// Moves the host to another seat before the game starts. A client sitting there gets the seat of the host in
+// exchange. The caller must call Broadcast to tell the clients.
+func (s *Server) MoveHost(seat int) error {
+	if s.Started {
+		return errors.New("seat error: the game has already started")
+	}
+	if seat < 0 || seat >= len(directions.Order) {
+		return errors.New(fmt.Sprintf("seat error: unexpected seat %d", seat))
+	}
+	if _, away := s.absent[seat]; away {
+		return errors.New(fmt.Sprintf("seat error: %s is kept for a player who lost the connection",
+			directions.String(seat)))
+	}
+
+	if p := s.seats[seat]; p != nil {
+		delete(s.seats, seat)
+		p.seat = s.HostSeat
+		p.ready = false
+		s.seats[p.seat] = p
+	}
+	s.HostSeat = seat
+	return nil
+}
+
+// Returns every seat of the table in directions.Order, with the person sitting there if any
+func (s *Server) Lobby() []protocol.LobbySeat {
+	lobby := make([]protocol.LobbySeat, 0, len(directions.Order))
+	for _, seat := range directions.Order {
+		entry := protocol.LobbySeat{Seat: seat}
+		if seat == s.HostSeat {
+			entry.Name, entry.Ready, entry.Host = s.HostName, true, true
+		} else if p := s.seats[seat]; p != nil {
+			entry.Name, entry.Ready = p.name, p.ready
+		} else if a, away := s.absent[seat]; away {
+			entry.Name, entry.Away = a.name, true
+		}
+		lobby = append(lobby, entry)
+	}
+	return lobby
+}
+
+// Reports whether every seated client is ready for the game to start
+func (s *Server) AllReady() bool {
+	for _, p := range s.seats {
+		if !p.ready {
+			return false
+		}
+	}
+	return true
+}
+
+// Cuts the name of a player to maxNameLength bytes without splitting a character
+func trimName(name string) string {
+	return cut(name, maxNameLength)
+}
+
+// Cuts the text to the given number of bytes without splitting a character
+func cut(text string, length int) string {
+	if len(text) <= length {
+		return text
+	}
+	runes := []rune(text)
+	for len(string(runes)) > length {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes)
+}
+
+// Sends a line of chat to every client and keeps it for the host. Refer to Messages
+func (s *Server) chat(seat int, name, text string) error {
+	text = cut(strings.TrimSpace(text), protocol.MaxChatLength)
+	if text == "" {
+		return errors.New("chat error: empty message")
+	}
+
+	chat := &protocol.Chat{Seat: seat, Name: name, Text: text}
+	s.sendAll(chat)
+	s.messages = append(s.messages, chat)
+	return nil
+}
+
+// Sends a reaction to every client and keeps it for the host. Only the players sitting at the table react
+func (s *Server) react(seat int, reaction string) error {
+	for _, known := range protocol.Reactions {
+		if known == reaction {
+			message := &protocol.Reaction{Seat: seat, Reaction: reaction}
+			s.sendAll(message)
+			s.messages = append(s.messages, message)
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("reaction error: unknown reaction %q", reaction))
+}
+
+// Sends a line of chat written by the host to every client
+func (s *Server) Say(text string) error {
+	return s.chat(s.HostSeat, s.HostName, text)
+}
+
+// Sends a reaction of the host to every client
+func (s *Server) React(reaction string) error {
+	return s.react(s.HostSeat, reaction)
+}
+
+// Returns the chat lines and the reactions sent since the last call, the host's included, as *protocol.Chat
+// and *protocol.Reaction
+func (s *Server) Messages() []protocol.Message {
+	messages := s.messages
+	s.messages = nil
+	return messages
+}
+
+// Reports whether a client sits at the seat
+func (s *Server) Seated(seat int) bool {
+	return s.seats[seat] != nil
+}
+
+// Reports whether the seat is kept for a client that lost its connection during the game
+func (s *Server) Away(seat int) bool {
+	_, away := s.absent[seat]
+	return away
+}
+
+// Returns the number of clients watching the game
+func (s *Server) Spectators() int {
+	return len(s.spectators)
+}
+
+// Returns the name of the player of every seat taken by a person, the host and the absent players included
+func (s *Server) Players() map[int]string {
+	players := map[int]string{s.HostSeat: s.HostName}
+	for seat, a := range s.absent {
+		players[seat] = a.name
+	}
+	for seat, p := range s.seats {
+		players[seat] = p.name
+	}
+	return players
+}
+
+// Sends a message to every seated client and every spectator
+func (s *Server) sendAll(message protocol.Message) {
+	for _, p := range s.seats {
+		p.send(message)
+	}
+	for p := range s.spectators {
+		p.send(message)
+	}
+}
+
+// Tells every seated client what happened since the last call (a new deal, the tricks collected, the hands
+// scored) followed by who sits where and the state of the match, each client only receiving its own cards.
+// The host must call it after every change it makes to the match itself.
+func (s *Server) Broadcast() {
+	// Back in the lobby, the seats kept for the absent players are given up
+	if !s.Started {
+		s.absent = make(map[int]absence)
+	}
+	if s.advertiser != nil {
+		s.advertiser.Set(s.advertisement())
+	}
+
+	g := s.Match.Hand
+	if g != s.lastHand && g.Phase != game.Dealing {
+		s.lastHand = g
+		s.lastTricks = 0
+		for seat, p := range s.seats {
+			p.send(&protocol.Deal{Dealer: g.Dealer, Trump: g.Trump, Hand: g.Hand(seat)})
+		}
+		for p := range s.spectators {
+			p.send(&protocol.Deal{Dealer: g.Dealer, Trump: g.Trump})
+		}
+	}
+
+	if g.Table != nil {
+		for ; s.lastTricks < len(g.Table.Tricks); s.lastTricks++ {
+			trick := g.Table.Tricks[s.lastTricks]
+			s.sendAll(&protocol.TrickResult{Trick: trick, Winner: trick.Winner(g.Trump).Seat})
+		}
+	}
+
+	score := s.Match.Score
+	for ; s.lastScores < len(score.Hands); s.lastScores++ {
+		s.sendAll(&protocol.Score{Hand: score.Hands[s.lastScores], Totals: score.Totals})
+	}
+
+	players := s.Players()
+	lobby := s.Lobby()
+	for _, p := range s.seats {
+		s.sendSnapshot(p, players, lobby)
+	}
+	for p := range s.spectators {
+		s.sendSnapshot(p, players, lobby)
+	}
+}
+
+// Sends who sits where and the whole state of the match as seen from the seat of the client, replacing what
+// the client was not sent yet. Spectators only see the hands when they are open
+func (s *Server) sendSnapshot(p *peer, players map[int]string, lobby []protocol.LobbySeat) {
+	if p.spectator {
+		p.sendSnapshot(&protocol.Lobby{Seat: protocol.Spectator, Seats: lobby, Started: s.Started},
+			protocol.NewSpectatorState(s.GameId, s.Match, s.HostSeat, players, s.OpenHands))
+		return
+	}
+	p.sendSnapshot(&protocol.Lobby{Seat: p.seat, Seats: lobby, Started: s.Started},
+		protocol.NewState(s.GameId, s.Match, p.seat, s.HostSeat, players))
+}
+
+// Replaces the match, for example when the host starts a new game, and sends it to every client
+func (s *Server) SetMatch(match *game.Match) {
+	s.Match = match
+	s.lastHand = nil
+	s.lastScores = 0
+	s.sendAll(&protocol.NewGame{Variant: match.Variant.Name()})
+	s.Broadcast()
+}
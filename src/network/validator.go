@@ -0,0 +1,61 @@
+package network
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"CardGameGo/src/protocol"
+	"CardGameGo/src/utils/directions"
+	"errors"
+	"fmt"
+)
+
+// Checks a game action of the client sitting at the seat against the rules engine before the host applies it,
+// without changing the game. A client may only act for its own seat, bid when it is its turn to, and play a
+// card it holds that follows the lead when it can, and only collect a complete trick it won. The host collects
+// the tricks won by its own seat and by the bots itself. Refer to rules.Table.CheckPlay for the rules of a
+// play. Other messages are not game actions and are always valid.
+func validate(g *game.Game, seat int, message protocol.Message) error {
+	switch m := message.(type) {
+	case *protocol.Bid:
+		if m.Seat != seat {
+			return actingFor(seat, m.Seat)
+		}
+		if g.Phase != game.Bidding {
+			return errors.New(fmt.Sprintf("bid error: cannot bid while %s", g.Phase))
+		}
+		if g.Current() != seat {
+			return errors.New(fmt.Sprintf("bid error: it is not %s's turn", directions.String(seat)))
+		}
+		return nil
+	case *protocol.PlayCard:
+		if m.Seat != seat {
+			return actingFor(seat, m.Seat)
+		}
+		if g.Phase != game.Playing {
+			return errors.New(fmt.Sprintf("play error: cannot play while %s", g.Phase))
+		}
+		if !cards.Contains(g.Hand(seat), m.Card) {
+			return errors.New(fmt.Sprintf("play error: %s does not hold %s", directions.String(seat), m.Card.Name()))
+		}
+		return g.Table.CheckPlay(seat, m.Card)
+	case *protocol.Claim:
+		if g.Phase != game.Playing {
+			return errors.New(fmt.Sprintf("claim error: cannot claim while %s", g.Phase))
+		}
+		if !g.Table.TrickComplete() {
+			return errors.New("claim error: the trick is not complete")
+		}
+		if winner := g.Table.Trick.Winner(g.Trump).Seat; winner != seat {
+			return errors.New(fmt.Sprintf("claim error: the trick was won by %s", directions.String(winner)))
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Returns the error of a client trying to act for a seat other than its own
+func actingFor(seat, other int) error {
+	return errors.New(fmt.Sprintf("request error: %s cannot act for %s", directions.String(seat),
+		directions.String(other)))
+}
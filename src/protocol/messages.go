@@ -0,0 +1,216 @@
+package protocol
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+)
+
+// The kinds of message. The kind of a message is written next to its content so that it can be decoded
+// without knowing what to expect. A kind must never be reused for a different content.
+const (
+	JoinType        = "join"
+	WelcomeType     = "welcome"
+	LeaveType       = "leave"
+	NewGameType     = "new_game"
+	DealType        = "deal"
+	BidType         = "bid"
+	PlayCardType    = "play_card"
+	ClaimType       = "claim"
+	TrickResultType = "trick_result"
+	ScoreType       = "score"
+	StateType       = "state"
+	ChatType        = "chat"
+	ErrorType       = "error"
+	TakeSeatType    = "take_seat"
+	ReadyType       = "ready"
+	RenameType      = "rename"
+	LobbyType       = "lobby"
+	ReactionType    = "reaction"
+)
+
+// The seat of a spectator, who watches the game without playing it
+const Spectator = -1
+
+// A single message exchanged between a client and the host
+type Message interface {
+	// Returns the kind of the message, one of the *Type constants
+	Type() string
+}
+
+// Client to host. Asks for a seat at the table, or to watch the game when Spectate is set. A client that lost
+// its connection joins again with the same id to get its seat back
+type Join struct {
+	Name     string `json:"name"`
+	Id       string `json:"id,omitempty"`
+	Spectate bool   `json:"spectate,omitempty"`
+}
+
+// Host to client. Answers a join with the seat given to the client, Spectator for a spectator
+type Welcome struct {
+	GameId string `json:"gameId"`
+	Seat   int    `json:"seat"`
+}
+
+// Host to client. A player left the table
+type Leave struct {
+	Seat   int    `json:"seat"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Host to client. The host started a new match
+type NewGame struct {
+	Variant string `json:"variant"`
+}
+
+// Host to client. A new hand was dealt. Only holds the cards of the seat the message is sent to, none for a
+// spectator
+type Deal struct {
+	Dealer int          `json:"dealer"`
+	Trump  cards.Suit   `json:"trump"`
+	Hand   []cards.Card `json:"hand"`
+}
+
+// Client to host. Bids for the client's seat
+type Bid struct {
+	Seat int `json:"seat"`
+	Bid  int `json:"bid"`
+}
+
+// Client to host. Plays a card for the client's seat
+type PlayCard struct {
+	Seat int        `json:"seat"`
+	Card cards.Card `json:"card"`
+}
+
+// Client to host. Collects the complete trick
+type Claim struct{}
+
+// Host to client. A trick was collected
+type TrickResult struct {
+	Trick  rules.Trick `json:"trick"`
+	Winner int         `json:"winner"`
+}
+
+// Host to client. A hand was scored
+type Score struct {
+	Hand   scoring.HandScore `json:"hand"`
+	Totals []int             `json:"totals"`
+}
+
+// Both ways. A line of text written by a player. The host fills in the seat and the name of the writer,
+// Spectator for a spectator
+type Chat struct {
+	Seat int    `json:"seat"`
+	Name string `json:"name,omitempty"`
+	Text string `json:"text"`
+}
+
+// Both ways. A quick reaction of a player, one of Reactions, shown next to its seat for a moment. The host
+// fills in the seat
+type Reaction struct {
+	Seat     int    `json:"seat"`
+	Reaction string `json:"reaction"`
+}
+
+// The reactions a player may send. The font of the application has no emoji so the faces are written as
+// emoticons
+var Reactions = []string{"Nice!", "Oops", "Well played", "Hurry up", ":)", ":(", ":D", ";)"}
+
+// The longest line of chat, in bytes. Longer lines are cut by the host
+const MaxChatLength = 200
+
+// Host to client. A request of the client was rejected
+type Error struct {
+	Message string `json:"message"`
+}
+
+// Client to host. Asks to move to a free seat before the game starts
+type TakeSeat struct {
+	Seat int `json:"seat"`
+}
+
+// Client to host. The client is ready for the game to start, or not anymore
+type Ready struct {
+	Ready bool `json:"ready"`
+}
+
+// Client to host. Changes the name the other players see
+type Rename struct {
+	Name string `json:"name"`
+}
+
+// A seat of the table as shown in the lobby
+type LobbySeat struct {
+	Seat int `json:"seat"`
+
+	// The name of the person sitting at the seat, empty when the seat is left to a bot
+	Name  string `json:"name,omitempty"`
+	Ready bool   `json:"ready,omitempty"`
+	Host  bool   `json:"host,omitempty"`
+
+	// Reports whether the person lost its connection. The seat is kept until it comes back
+	Away bool `json:"away,omitempty"`
+}
+
+// Host to client. Who sits where, sent whenever the seats change. Seat is the seat of the client the message
+// is sent to, which changes when it moves or when the host swaps seats with it.
+type Lobby struct {
+	Seat    int         `json:"seat"`
+	Seats   []LobbySeat `json:"seats"`
+	Started bool        `json:"started"`
+}
+
+// A message of a kind this version of the protocol does not know, usually sent by a newer version of the
+// application. It is decoded rather than dropped so that the connection can carry on.
+type Unknown struct {
+	Version int
+	Kind    string
+	Data    []byte
+}
+
+func (*Join) Type() string        { return JoinType }
+func (*Welcome) Type() string     { return WelcomeType }
+func (*Leave) Type() string       { return LeaveType }
+func (*NewGame) Type() string     { return NewGameType }
+func (*Deal) Type() string        { return DealType }
+func (*Bid) Type() string         { return BidType }
+func (*PlayCard) Type() string    { return PlayCardType }
+func (*Claim) Type() string       { return ClaimType }
+func (*TrickResult) Type() string { return TrickResultType }
+func (*Score) Type() string       { return ScoreType }
+func (*State) Type() string       { return StateType }
+func (*Chat) Type() string        { return ChatType }
+func (*Error) Type() string       { return ErrorType }
+func (*TakeSeat) Type() string    { return TakeSeatType }
+func (*Ready) Type() string       { return ReadyType }
+func (*Rename) Type() string      { return RenameType }
+func (*Lobby) Type() string       { return LobbyType }
+func (*Reaction) Type() string    { return ReactionType }
+func (u *Unknown) Type() string   { return u.Kind }
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Creates an empty message of every known kind, ready to be decoded into
+var registry = map[string]func() Message{
+	JoinType:        func() Message { return &Join{} },
+	WelcomeType:     func() Message { return &Welcome{} },
+	LeaveType:       func() Message { return &Leave{} },
+	NewGameType:     func() Message { return &NewGame{} },
+	DealType:        func() Message { return &Deal{} },
+	BidType:         func() Message { return &Bid{} },
+	PlayCardType:    func() Message { return &PlayCard{} },
+	ClaimType:       func() Message { return &Claim{} },
+	TrickResultType: func() Message { return &TrickResult{} },
+	ScoreType:       func() Message { return &Score{} },
+	StateType:       func() Message { return &State{} },
+	ChatType:        func() Message { return &Chat{} },
+	ErrorType:       func() Message { return &Error{} },
+	TakeSeatType:    func() Message { return &TakeSeat{} },
+	ReadyType:       func() Message { return &Ready{} },
+	RenameType:      func() Message { return &Rename{} },
+	LobbyType:       func() Message { return &Lobby{} },
+	ReactionType:    func() Message { return &Reaction{} },
+}
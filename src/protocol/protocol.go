@@ -0,0 +1,154 @@
+// The messages exchanged between the devices playing at the same table, and their encoding. Every message is
+// written as a frame: a 4 byte big endian length followed by that many bytes of JSON holding the version of
+// the protocol, the kind of the message and its content:
+//
+//	{"v":1,"type":"play_card","data":{"seat":2,"card":"sQ"}}
+//
+// Cards are written with their asset names (see src/cards) and seats with the direction constants (see
+// src/utils/directions).
+//
+// Compatibility rules:
+//   - Fields may be added to a message without changing Version. Older versions ignore them.
+//   - Kinds of message may be added at any time. Older versions decode them as *Unknown along with
+//     ErrUnknownMessage and carry on with the next frame.
+//   - Frames of a newer version are decoded like the others, the fields this version does not know being
+//     ignored.
+//   - Any other change (removing or renaming a field, changing its meaning) requires a new Version. Frames of
+//     a version older than MinVersion are rejected with ErrUnsupportedVersion.
+//
+// Like the rules engine, this package must never import SDL.
+package protocol
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// The version of the protocol written by this version of the application
+const Version = 1
+
+// The oldest version of the protocol this version of the application can read
+const MinVersion = 1
+
+// The size of the largest frame accepted, in bytes
+const MaxFrameSize = 1 << 20
+
+// Returned along with an *Unknown message when a frame holds a kind of message this version does not know.
+// The frame was read entirely, the next one can be read as usual.
+var ErrUnknownMessage = errors.New("protocol error: unknown message")
+
+// Returned when a frame was written with a version of the protocol older than MinVersion. The frame was read
+// entirely, the next one can be read as usual.
+var ErrUnsupportedVersion = errors.New("protocol error: unsupported version")
+
+// Returned when a frame does not hold a valid message. The frame was read entirely, the next one can be read
+// as usual.
+var ErrMalformedMessage = errors.New("protocol error: malformed message")
+
+// Returned when a frame is larger than MaxFrameSize. The connection cannot be used anymore.
+var ErrFrameTooLarge = errors.New("protocol error: frame too large")
+
+// The JSON object of a frame
+type envelope struct {
+	Version int             `json:"v"`
+	Type    string          `json:"type"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Encodes a message as the JSON object of a frame, without the length prefix
+func Encode(message Message) ([]byte, error) {
+	if unknown, ok := message.(*Unknown); ok {
+		return json.Marshal(envelope{Version: unknown.Version, Type: unknown.Kind, Data: unknown.Data})
+	}
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{Version: Version, Type: message.Type(), Data: data})
+}
+
+// Decodes the JSON object of a frame. Refer to the package documentation for the handling of unknown kinds
+// of message and of other versions of the protocol.
+func Decode(frame []byte) (Message, error) {
+	e := envelope{}
+	err := json.Unmarshal(frame, &e)
+	if err != nil {
+		return nil, ErrMalformedMessage
+	}
+	if e.Version < MinVersion {
+		return nil, ErrUnsupportedVersion
+	}
+
+	create, ok := registry[e.Type]
+	if !ok {
+		return &Unknown{Version: e.Version, Kind: e.Type, Data: e.Data}, ErrUnknownMessage
+	}
+
+	message := create()
+	if len(e.Data) > 0 {
+		err = json.Unmarshal(e.Data, message)
+		if err != nil {
+			return nil, ErrMalformedMessage
+		}
+	}
+	return message, nil
+}
+
+// Encodes a message as a whole frame, length prefix included
+func Frame(message Message) ([]byte, error) {
+	data, err := Encode(message)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxFrameSize {
+		return nil, ErrFrameTooLarge
+	}
+
+	frame := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(frame, uint32(len(data)))
+	copy(frame[4:], data)
+	return frame, nil
+}
+
+// Writes a message as a single frame
+func Write(w io.Writer, message Message) error {
+	frame, err := Frame(message)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(frame)
+	return err
+}
+
+// Reads the next frame and decodes its message. Errors of the reader, io.EOF included, are returned as is.
+// Refer to Decode for the other errors.
+func Read(r io.Reader) (Message, error) {
+	var prefix [4]byte
+	_, err := io.ReadFull(r, prefix[:])
+	if err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(prefix[:])
+	if size > MaxFrameSize {
+		return nil, ErrFrameTooLarge
+	}
+	frame := make([]byte, size)
+	_, err = io.ReadFull(r, frame)
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return Decode(frame)
+}
+
+// Reports whether the next frame can still be read after the error returned by Read. The frame that could
+// not be decoded was skipped.
+func Recoverable(err error) bool {
+	return err == ErrUnknownMessage || err == ErrUnsupportedVersion || err == ErrMalformedMessage
+}
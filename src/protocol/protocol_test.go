@@ -0,0 +1,272 @@
+package protocol
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// A message of every kind, with every field set
+func allMessages(t *testing.T) []Message {
+	match := game.NewMatch(variants.Spades{}, directions.East, 7)
+	err := match.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trick := rules.Trick{Leader: directions.South, Plays: []rules.Play{
+		{Seat: directions.South, Card: cards.MustParse("hK")},
+		{Seat: directions.West, Card: cards.MustParse("h1")},
+		{Seat: directions.North, Card: cards.MustParse("h2")},
+		{Seat: directions.East, Card: cards.MustParse("sX")},
+	}}
+	hand := scoring.HandScore{Dealer: directions.East, Teams: []scoring.TeamScore{
+		{Tricks: 5, Bid: 4, Points: 41, Bags: 1},
+		{Tricks: 8, Bid: 9, Points: -90},
+	}}
+
+	return []Message{
+		&Join{Name: "Alice", Id: "k3j9", Spectate: true},
+		&Welcome{GameId: "table", Seat: directions.West},
+		&Leave{Seat: directions.North, Reason: "connection lost"},
+		&NewGame{Variant: "Hearts"},
+		&Deal{Dealer: directions.South, Trump: cards.Spades, Hand: []cards.Card{
+			cards.MustParse("c1"), cards.MustParse("dX"), cards.MustParse("hQ"), cards.MustParse("s2"),
+		}},
+		&Bid{Seat: directions.East, Bid: 4},
+		&PlayCard{Seat: directions.South, Card: cards.MustParse("sQ")},
+		&Claim{},
+		&TrickResult{Trick: trick, Winner: directions.East},
+		&Score{Hand: hand, Totals: []int{41, -90}},
+		NewState("table", match, directions.North, directions.East, map[int]string{directions.East: "host"}),
+		NewSpectatorState("table", match, directions.East, map[int]string{directions.East: "host"}, true),
+		&Chat{Seat: directions.West, Name: "Dave", Text: "well played"},
+		&Reaction{Seat: directions.South, Reaction: "Oops"},
+		&Error{Message: "play error: it is not North's turn"},
+		&TakeSeat{Seat: directions.South},
+		&Ready{Ready: true},
+		&Rename{Name: "Bob"},
+		&Lobby{Seat: directions.North, Started: true, Seats: []LobbySeat{
+			{Seat: directions.North, Name: "Bob", Ready: true},
+			{Seat: directions.East, Name: "host", Host: true},
+			{Seat: directions.South, Name: "Carol", Away: true},
+			{Seat: directions.West},
+		}},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, message := range allMessages(t) {
+		buffer := &bytes.Buffer{}
+		err := Write(buffer, message)
+		if err != nil {
+			t.Fatalf("%s: %s", message.Type(), err)
+		}
+
+		decoded, err := Read(buffer)
+		if err != nil {
+			t.Fatalf("%s: %s", message.Type(), err)
+		}
+		if !reflect.DeepEqual(decoded, message) {
+			t.Errorf("%s: decoded %+v, expected %+v", message.Type(), decoded, message)
+		}
+		if buffer.Len() != 0 {
+			t.Errorf("%s: %d bytes left after the frame", message.Type(), buffer.Len())
+		}
+	}
+}
+
+func TestEveryKindIsRegistered(t *testing.T) {
+	for _, message := range allMessages(t) {
+		if _, ok := registry[message.Type()]; !ok {
+			t.Errorf("%s is not registered", message.Type())
+		}
+	}
+}
+
+func TestConsecutiveFrames(t *testing.T) {
+	messages := allMessages(t)
+	buffer := &bytes.Buffer{}
+	for _, message := range messages {
+		err := Write(buffer, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, message := range messages {
+		decoded, err := Read(buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decoded.Type() != message.Type() {
+			t.Fatalf("read %s, expected %s", decoded.Type(), message.Type())
+		}
+	}
+	if _, err := Read(buffer); err != io.EOF {
+		t.Fatalf("expected io.EOF after the last frame, got %v", err)
+	}
+}
+
+// Writes a frame holding the given JSON object as is
+func writeRaw(buffer *bytes.Buffer, data string) {
+	var prefix [4]byte
+	binary.BigEndian.PutUint32(prefix[:], uint32(len(data)))
+	buffer.Write(prefix[:])
+	buffer.WriteString(data)
+}
+
+func TestUnknownMessagesAreSkipped(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writeRaw(buffer, `{"v":2,"type":"emote","data":{"seat":1,"emote":"wave"}}`)
+	err := Write(buffer, &Claim{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message, err := Read(buffer)
+	if err != ErrUnknownMessage || !Recoverable(err) {
+		t.Fatalf("expected ErrUnknownMessage, got %v", err)
+	}
+	unknown, ok := message.(*Unknown)
+	if !ok || unknown.Kind != "emote" || unknown.Version != 2 {
+		t.Fatalf("expected the unknown message, got %+v", message)
+	}
+
+	// The unknown message is forwarded unchanged when encoded again
+	frame, err := Encode(unknown)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := Decode(frame)
+	if err != ErrUnknownMessage || !reflect.DeepEqual(again, unknown) {
+		t.Fatalf("the unknown message changed: %+v", again)
+	}
+
+	// The next frame is read as usual
+	message, err = Read(buffer)
+	if err != nil || message.Type() != ClaimType {
+		t.Fatalf("expected the claim after the unknown message, got %v, %v", message, err)
+	}
+}
+
+func TestNewerVersionsIgnoreUnknownFields(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	writeRaw(buffer, `{"v":3,"type":"play_card","data":{"seat":2,"card":"sQ","animation":"flip"}}`)
+
+	message, err := Read(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &PlayCard{Seat: directions.South, Card: cards.MustParse("sQ")}
+	if !reflect.DeepEqual(message, expected) {
+		t.Fatalf("decoded %+v, expected %+v", message, expected)
+	}
+}
+
+func TestInvalidFrames(t *testing.T) {
+	frames := map[string]error{
+		`{"v":0,"type":"claim"}`:                          ErrUnsupportedVersion,
+		`{"v":1,"type":"play_card","data":{"card":"z9"}}`: ErrMalformedMessage,
+		`not json`: ErrMalformedMessage,
+	}
+	for frame, expected := range frames {
+		buffer := &bytes.Buffer{}
+		writeRaw(buffer, frame)
+		_, err := Read(buffer)
+		if err != expected || !Recoverable(err) {
+			t.Errorf("%s: expected %v, got %v", frame, expected, err)
+		}
+	}
+
+	// A frame announcing more than MaxFrameSize bytes
+	buffer := &bytes.Buffer{}
+	var prefix [4]byte
+	binary.BigEndian.PutUint32(prefix[:], MaxFrameSize+1)
+	buffer.Write(prefix[:])
+	if _, err := Read(buffer); err != ErrFrameTooLarge || Recoverable(err) {
+		t.Errorf("expected ErrFrameTooLarge, got %v", err)
+	}
+
+	// A frame cut short
+	buffer = &bytes.Buffer{}
+	writeRaw(buffer, `{"v":1,"type":"claim"}`)
+	buffer.Truncate(buffer.Len() - 3)
+	if _, err := Read(buffer); err != io.ErrUnexpectedEOF || Recoverable(err) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+func TestStateRebuildsTheMatch(t *testing.T) {
+	match := game.NewMatch(variants.CourtPiece{}, directions.East, 42)
+	err := match.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := match.Hand
+	for i := 0; i < 6; i++ {
+		err = g.Play(g.Current(), g.LegalMoves(g.Current())[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g.Table.TrickComplete() {
+			_, err = g.Collect()
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	for _, seat := range directions.Order {
+		rebuilt, err := NewState("table", match, seat, directions.East, nil).Match()
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := rebuilt.Hand
+		if r.Phase != g.Phase || r.Current() != g.Current() || r.Trump != g.Trump {
+			t.Fatalf("%s: the rebuilt match does not match the original", directions.String(seat))
+		}
+		if !reflect.DeepEqual(r.Hand(seat), g.Hand(seat)) || !reflect.DeepEqual(r.Table.Trick, g.Table.Trick) {
+			t.Fatalf("%s: the rebuilt hand or trick differs", directions.String(seat))
+		}
+		if !reflect.DeepEqual(r.LegalMoves(seat), g.LegalMoves(seat)) {
+			t.Fatalf("%s: the rebuilt match does not allow the same moves", directions.String(seat))
+		}
+	}
+}
+
+func TestSpectatorStates(t *testing.T) {
+	match := game.NewMatch(variants.Hearts{}, directions.East, 42)
+	err := match.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closed, err := NewSpectatorState("table", match, directions.East, nil, false).Match()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, seat := range directions.Order {
+		if len(closed.Hand.Hand(seat)) != 0 {
+			t.Fatalf("a spectator sees the hand of %s", directions.String(seat))
+		}
+	}
+
+	open, err := NewSpectatorState("table", match, directions.East, nil, true).Match()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, seat := range directions.Order {
+		if !reflect.DeepEqual(open.Hand.Hand(seat), match.Hand.Hand(seat)) {
+			t.Fatalf("a spectator does not see the hand of %s although the hands are open", directions.String(seat))
+		}
+	}
+}
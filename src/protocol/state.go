@@ -0,0 +1,139 @@
+package protocol
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"errors"
+	"fmt"
+)
+
+// The state of a match as seen from a single seat. It holds everything that is public (the bids, the cards
+// played, the score) but only the cards of the seat it is sent to. The seed of the deal is never sent since
+// it would reveal every hand. Spectators are sent the state seen from the Spectator seat, which holds no
+// cards unless the host opened the hands.
+type State struct {
+	GameId  string `json:"gameId"`
+	Variant string `json:"variant"`
+
+	// The seat the state is seen from and the seat of the host
+	Seat     int `json:"seat"`
+	HostSeat int `json:"hostSeat"`
+
+	// The name of the player of every seat taken by a person, keyed by direction
+	Players map[int]string `json:"players"`
+
+	Phase   game.Phase `json:"phase"`
+	Dealer  int        `json:"dealer"`
+	Current int        `json:"current"`
+	Trump   cards.Suit `json:"trump"`
+
+	// The cards held by the seat
+	Hand []cards.Card `json:"hand"`
+
+	// The cards held by every seat, keyed by direction. Only sent to the spectators of a game whose host
+	// opened the hands
+	Hands map[int][]cards.Card `json:"hands,omitempty"`
+
+	// The bids made so far, keyed by direction. Nil for variants without a bidding phase
+	Bids map[int]int `json:"bids"`
+
+	// The trick being played, the tricks already collected and the number of tricks won by every seat
+	Trick     rules.Trick   `json:"trick"`
+	Tricks    []rules.Trick `json:"tricks"`
+	TricksWon map[int]int   `json:"tricksWon"`
+
+	// The score of the match
+	Score *scoring.Match `json:"score"`
+}
+
+// Returns the state of the match as seen from the seat. It is built from the view of the seat, refer to
+// game.Game.View, so that it can never hold the cards of another seat
+func NewState(gameId string, match *game.Match, seat, hostSeat int, players map[int]string) *State {
+	g := match.Hand.View(seat)
+	state := &State{
+		GameId:   gameId,
+		Variant:  match.Variant.Name(),
+		Seat:     seat,
+		HostSeat: hostSeat,
+		Players:  players,
+		Phase:    g.Phase,
+		Dealer:   g.Dealer,
+		Current:  g.Current(),
+		Trump:    g.Trump,
+		Hand:     g.Hand(seat),
+		Score:    match.Score,
+	}
+	if g.Auction != nil {
+		state.Bids = g.Auction.Bids
+	}
+	if g.Table != nil {
+		state.Trick = g.Table.Trick
+		state.Tricks = g.Table.Tricks
+		state.TricksWon = g.Table.TricksWon
+	}
+	return state
+}
+
+// Returns the state of the match as seen by a spectator. Every hand is included when the hands are open
+func NewSpectatorState(gameId string, match *game.Match, hostSeat int, players map[int]string,
+	openHands bool) *State {
+
+	state := NewState(gameId, match, Spectator, hostSeat, players)
+	if openHands {
+		state.Hands = make(map[int][]cards.Card)
+		for _, seat := range directions.Order {
+			state.Hands[seat] = match.Hand.Hand(seat)
+		}
+	}
+	return state
+}
+
+// Rebuilds a match from the state, with the hands the state does not hold left empty. The match is only meant to be
+// drawn and to check which moves are legal, the actions themselves must be sent to the server.
+func (s *State) Match() (*game.Match, error) {
+	variant, ok := variants.ByName(s.Variant)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("state error: unknown variant %q", s.Variant))
+	}
+
+	hands := make(map[int][]cards.Card)
+	for _, seat := range directions.Order {
+		hands[seat] = s.Hands[seat]
+	}
+	if s.Seat != Spectator {
+		hands[s.Seat] = s.Hand
+	}
+
+	g := game.New(variant, s.Dealer, 0)
+	g.Phase = s.Phase
+	g.Trump = s.Trump
+	g.Dealt = hands
+
+	if g.Auction = variants.NewAuction(variant, s.Dealer); g.Auction != nil {
+		for seat, bid := range s.Bids {
+			g.Auction.Bids[seat] = bid
+		}
+		g.Auction.Current = s.Current
+	}
+
+	if s.Phase == game.Playing || s.Phase == game.HandOver {
+		g.Table = variants.NewTable(variant, hands, s.Dealer)
+		g.Table.Trump = s.Trump
+		g.Table.Current = s.Current
+		g.Table.Trick = s.Trick
+		g.Table.Tricks = s.Tricks
+		for seat, won := range s.TricksWon {
+			g.Table.TricksWon[seat] = won
+		}
+	}
+
+	score := s.Score
+	if score == nil {
+		score = scoring.NewMatch(variant.Teams())
+	}
+	return &game.Match{Variant: variant, Hand: g, Score: score}, nil
+}
@@ -0,0 +1,294 @@
+// Records hands move by move so that they can be replayed, for example to settle a dispute about what was
+// played or to debug the rules of a variant. A record holds the hands as they were dealt followed by every
+// move of the hand in order, which is enough to rebuild the game after any move. Refer to Record.Steps
+//
+// A record is written as a JSON object, one file per hand:
+//
+//	{
+//	  "version": 1,                  // the version of the format, refer to Version
+//	  "gameId": "4f2a...",           // the game the hand belongs to
+//	  "variant": "Spades",           // the name of the variant, refer to variants.ByName
+//	  "hand": 3,                     // the number of the hand in its match, starting from 1
+//	  "seed": 1234,                  // the seed the hands were dealt from, for information only
+//	  "dealer": 1,                   // the seat that dealt, 0 for North, 1 for East, 2 for South, 3 for West
+//	  "trump": 4,                    // the trump suit, 0 for none, then clubs, diamonds, hearts and spades
+//	  "seat": 2,                     // the seat of the device that recorded the hand
+//	  "players": {"2": "Alice"},     // the name of the players, the seats played by bots being left out
+//	  "hands": {"0": ["c2", "sQ", ...], ...},
+//	  "moves": [
+//	    {"kind": "bid", "seat": 2, "bid": 3},
+//	    {"kind": "play", "seat": 2, "card": "sQ"},
+//	    {"kind": "collect", "seat": 2},   // the trick was collected, seat is the seat that won it
+//	    ...
+//	  ],
+//	  "score": {...}                 // the score of the hand once it is over, refer to scoring.HandScore
+//	}
+//
+// The cards are written using the asset naming scheme, refer to cards.Card.MarshalText. Like the rules
+// engine, this package must never import SDL so that the records can be read by headless tools.
+package record
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/game"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The version of the record format. Records of another version are not read
+const Version = 1
+
+// The extension of the files records are written to
+const extension = ".json"
+
+// The kinds of move
+const (
+	// A seat bid, refer to Move.Bid
+	BidMove = "bid"
+
+	// A seat played a card, refer to Move.Card
+	PlayMove = "play"
+
+	// The complete trick was collected by the seat that won it
+	CollectMove = "collect"
+)
+
+// A single move of a hand
+type Move struct {
+	// One of the *Move constants
+	Kind string `json:"kind"`
+
+	// The seat that made the move. For a collected trick, the seat that won it
+	Seat int `json:"seat"`
+
+	Bid  int        `json:"bid,omitempty"`
+	Card cards.Card `json:"card"`
+}
+
+// Leaves the card out of the moves that do not play one, as documented above. The zero card is written as an
+// empty string, but omitempty has no effect on a struct
+func (m Move) MarshalJSON() ([]byte, error) {
+	type move Move
+	encoded := struct {
+		move
+		Card *cards.Card `json:"card,omitempty"`
+	}{move: move(m)}
+	if m.Kind == PlayMove {
+		encoded.Card = &m.Card
+	}
+	return json.Marshal(encoded)
+}
+
+// Describes the move, for example "North plays Queen of Spades"
+func (m Move) String() string {
+	seat := directions.String(m.Seat)
+	switch m.Kind {
+	case BidMove:
+		return seat + " bids " + variants.BidString(m.Bid)
+	case PlayMove:
+		return seat + " plays " + m.Card.Name()
+	case CollectMove:
+		return seat + " wins the trick"
+	default:
+		return seat + " " + m.Kind
+	}
+}
+
+type Record struct {
+	Version int    `json:"version"`
+	GameId  string `json:"gameId,omitempty"`
+	Variant string `json:"variant"`
+
+	// The number of the hand in its match, starting from 1
+	Hand int `json:"hand"`
+
+	Seed   int64      `json:"seed"`
+	Dealer int        `json:"dealer"`
+	Trump  cards.Suit `json:"trump"`
+
+	// The seat of the device that recorded the hand, which the replay is seen from
+	Seat int `json:"seat"`
+
+	// The name of every player, keyed by direction. The seats played by bots are left out
+	Players map[int]string `json:"players,omitempty"`
+
+	// The hands as they were dealt, keyed by direction
+	Hands map[int][]cards.Card `json:"hands"`
+
+	// Every move of the hand, in the order they were made
+	Moves []Move `json:"moves"`
+
+	// The score of the hand, or nil if the hand was not over when it was recorded
+	Score *scoring.HandScore `json:"score,omitempty"`
+}
+
+// Returns the record of the hand as played so far. The bids are made in turn from the seat after the
+// dealer, so their order is known from the seats alone.
+func New(g *game.Game) *Record {
+	r := &Record{
+		Version: Version,
+		Variant: g.Variant.Name(),
+		Seed:    g.Seed,
+		Dealer:  g.Dealer,
+		Trump:   g.Trump,
+		Hands:   g.Dealt,
+		Moves:   []Move{},
+	}
+
+	if g.Auction != nil {
+		for _, seat := range directions.From(directions.Next(g.Dealer)) {
+			if bid, ok := g.Auction.BidOf(seat); ok {
+				r.Moves = append(r.Moves, Move{Kind: BidMove, Seat: seat, Bid: bid})
+			}
+		}
+	}
+
+	if g.Table != nil {
+		for _, trick := range g.Table.Tricks {
+			for _, play := range trick.Plays {
+				r.Moves = append(r.Moves, Move{Kind: PlayMove, Seat: play.Seat, Card: play.Card})
+			}
+			r.Moves = append(r.Moves, Move{Kind: CollectMove, Seat: trick.Winner(g.Trump).Seat})
+		}
+		for _, play := range g.Table.Trick.Plays {
+			r.Moves = append(r.Moves, Move{Kind: PlayMove, Seat: play.Seat, Card: play.Card})
+		}
+	}
+	return r
+}
+
+// Replays the hand, returning the game right after the deal followed by the game after every move. Every
+// move is checked by the rules engine, so a record that was tampered with fails to replay.
+func (r *Record) Steps() ([]*game.Game, error) {
+	variant, ok := variants.ByName(r.Variant)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("record error: unknown variant %q", r.Variant))
+	}
+
+	// The hand is rebuilt from the cards dealt rather than from the seed, so that the record does not depend
+	// on how the deck is shuffled
+	snapshot := &game.Snapshot{
+		Variant:  r.Variant,
+		HandSeed: r.Seed,
+		Dealer:   r.Dealer,
+		Trump:    r.Trump,
+		Dealt:    r.Hands,
+		Hands:    r.Hands,
+	}
+	if variant.HasBidding() {
+		snapshot.Phase = game.Bidding
+		snapshot.Current = directions.Next(r.Dealer)
+	} else {
+		snapshot.Phase = game.Playing
+		snapshot.Current = variant.FirstLeader(r.Hands, r.Dealer)
+		snapshot.Trick = rules.Trick{Leader: snapshot.Current}
+	}
+	match, err := snapshot.Match()
+	if err != nil {
+		return nil, err
+	}
+
+	g := match.Hand
+	steps := []*game.Game{g.Clone()}
+	for i, move := range r.Moves {
+		switch move.Kind {
+		case BidMove:
+			err = g.Bid(move.Seat, move.Bid)
+		case PlayMove:
+			err = g.Play(move.Seat, move.Card)
+		case CollectMove:
+			var winner int
+			winner, err = g.Collect()
+			if err == nil && winner != move.Seat {
+				err = errors.New(fmt.Sprintf("collect error: the trick was won by %s", directions.String(winner)))
+			}
+		default:
+			err = errors.New(fmt.Sprintf("move error: unknown move %q", move.Kind))
+		}
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("record error: move %d: %s", i+1, err))
+		}
+		steps = append(steps, g.Clone())
+	}
+	return steps, nil
+}
+
+// Writes the record as indented JSON
+func Write(w io.Writer, r *Record) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// Reads a record written by Write
+func Read(reader io.Reader) (*Record, error) {
+	var r Record
+	err := json.NewDecoder(reader).Decode(&r)
+	if err != nil {
+		return nil, err
+	}
+	if r.Version != Version {
+		return nil, errors.New(fmt.Sprintf("record error: unsupported version %d", r.Version))
+	}
+	return &r, nil
+}
+
+// Writes the record to a new file of the directory, named after the time it was written so that the files
+// are listed in the order the hands were played. Returns the path of the file.
+func WriteFile(dir string, r *Record) (string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("%s-%03d%s", time.Now().Format("20060102-150405.000"), r.Hand, extension)
+	path := filepath.Join(dir, name)
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	return path, Write(file, r)
+}
+
+// Reads the record of a file written by WriteFile
+func ReadFile(path string) (*Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Read(file)
+}
+
+// Returns the path of every record of the directory, the oldest first. A missing directory holds no records
+func List(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), extension) {
+			paths = append(paths, filepath.Join(dir, info.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
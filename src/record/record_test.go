@@ -0,0 +1,102 @@
+package record
+
+import (
+	"CardGameGo/src/game"
+	"CardGameGo/src/utils/directions"
+	"CardGameGo/src/variants"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// Plays a whole hand of the variant, every seat making its first legal move
+func playHand(t *testing.T, variant variants.Variant) *game.Game {
+	g := game.New(variant, directions.East, 42)
+	err := g.Deal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for g.Phase != game.HandOver {
+		switch {
+		case g.Phase == game.Bidding:
+			err = g.Bid(g.Current(), g.LegalBids(g.Current())[0])
+		case g.Table.TrickComplete():
+			_, err = g.Collect()
+		default:
+			err = g.Play(g.Current(), g.LegalMoves(g.Current())[0])
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+func TestRecordsReplayTheHand(t *testing.T) {
+	for _, variant := range variants.All {
+		g := playHand(t, variant)
+
+		buffer := &bytes.Buffer{}
+		err := Write(buffer, New(g))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := Read(buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		steps, err := r.Steps()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(steps) != len(r.Moves)+1 {
+			t.Fatalf("%s: %d steps for %d moves", variant.Name(), len(steps), len(r.Moves))
+		}
+		last := steps[len(steps)-1]
+		if last.Phase != game.HandOver || !reflect.DeepEqual(last.Result(), g.Result()) {
+			t.Fatalf("%s: the replay ended differently", variant.Name())
+		}
+
+		// The first step is the deal, before any card is played
+		for _, seat := range directions.Order {
+			if !reflect.DeepEqual(steps[0].Hand(seat), g.Dealt[seat]) {
+				t.Fatalf("%s: the replay did not start from the deal", variant.Name())
+			}
+		}
+	}
+}
+
+func TestTamperedRecordsDoNotReplay(t *testing.T) {
+	g := playHand(t, variants.All[0])
+	r := New(g)
+
+	// Swap two plays of the first trick
+	for i, move := range r.Moves {
+		if move.Kind == PlayMove {
+			r.Moves[i], r.Moves[i+1] = r.Moves[i+1], r.Moves[i]
+			break
+		}
+	}
+	if _, err := r.Steps(); err == nil {
+		t.Fatal("a tampered record was replayed")
+	}
+}
+
+func TestOnlyPlaysHoldACard(t *testing.T) {
+	for _, move := range New(playHand(t, variants.Spades{})).Moves {
+		data, err := json.Marshal(move)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]interface{}
+		err = json.Unmarshal(data, &fields)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := fields["card"]; ok != (move.Kind == PlayMove) {
+			t.Fatalf("unexpected card in %s", data)
+		}
+	}
+}
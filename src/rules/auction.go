@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"CardGameGo/src/utils/directions"
+	"errors"
+	"fmt"
+)
+
+// Returns the bids a seat may make given the bids made so far. Game variants provide this function since
+// the legal bids differ from one game to another.
+type BidRule func(a *Auction, seat int) []int
+
+// The bidding round played before the first trick of a hand. Every seat bids exactly once, in
+// directions.Order starting with the first bidder.
+type Auction struct {
+	// The seat whose turn it is to bid
+	Current int
+
+	// The bid of every seat that has already bid, keyed by direction
+	Bids map[int]int
+
+	// The variant specific rule deciding which bids are legal
+	Rule BidRule
+}
+
+// Provided constructor
+func NewAuction(first int, rule BidRule) *Auction {
+	return &Auction{
+		Current: first,
+		Bids:    make(map[int]int),
+		Rule:    rule,
+	}
+}
+
+// Reports whether every seat has bid
+func (a *Auction) Complete() bool {
+	return len(a.Bids) == len(directions.Order)
+}
+
+// Returns the bid of the seat and whether the seat has bid yet
+func (a *Auction) BidOf(seat int) (int, bool) {
+	bid, ok := a.Bids[seat]
+	return bid, ok
+}
+
+// Returns the bids the given seat may make right now, which is nothing if it is not the seat's turn
+func (a *Auction) LegalBids(seat int) []int {
+	if a.Complete() || seat != a.Current {
+		return nil
+	}
+	return a.Rule(a, seat)
+}
+
+// Reports whether the seat may make the given bid, returning an error describing the reason if not
+func (a *Auction) CheckBid(seat, bid int) error {
+	if a.Complete() {
+		return errors.New("bid error: the bidding is over")
+	}
+	if seat != a.Current {
+		return errors.New(fmt.Sprintf("bid error: it is not %s's turn", directions.String(seat)))
+	}
+	for _, legal := range a.LegalBids(seat) {
+		if legal == bid {
+			return nil
+		}
+	}
+	return errors.New(fmt.Sprintf("bid error: %s cannot bid %d", directions.String(seat), bid))
+}
+
+// Records a bid for the seat after validating it and moves the turn to the next seat
+func (a *Auction) Bid(seat, bid int) error {
+	err := a.CheckBid(seat, bid)
+	if err != nil {
+		return err
+	}
+
+	a.Bids[seat] = bid
+	a.Current = directions.Next(seat)
+	return nil
+}
+
+// Returns a copy of the auction that can be bid on without affecting the original
+func (a *Auction) Clone() *Auction {
+	clone := NewAuction(a.Current, a.Rule)
+	for seat, bid := range a.Bids {
+		clone.Bids[seat] = bid
+	}
+	return clone
+}
@@ -0,0 +1,290 @@
+// A rules engine for four seat trick taking games. The engine knows nothing about rendering (it must never
+// import SDL) and is meant to be the single source of truth for the state of a hand: who holds which cards,
+// what has been played to the current trick, whose turn it is and who won the previous tricks.
+//
+// The user interface (see src/managers/gamemanager) only ever reads from a Table and forwards the player's
+// actions to it, which means every action is validated in one place regardless of where it came from.
+//
+// Seats are identified by the direction constants in src/utils/directions and turns always advance in
+// directions.Order.
+package rules
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/utils/directions"
+	"errors"
+	"fmt"
+)
+
+// A Restriction narrows down the cards a seat may play on top of the basic follow suit rule. Game variants
+// use restrictions for rules such as "hearts cannot be led until a heart has been discarded". A restriction
+// receives the cards that are legal under the follow suit rule and returns the subset that remains legal. If
+// a restriction returns no cards at all it is ignored, so that a seat is never left without a legal move.
+type Restriction func(t *Table, seat int, legal []cards.Card) []cards.Card
+
+// A single card played by a seat
+type Play struct {
+	Seat int
+	Card cards.Card
+}
+
+// The cards played to a single trick, in the order they were played
+type Trick struct {
+	// The seat that led the trick
+	Leader int
+	Plays  []Play
+}
+
+// The state of a single hand being played
+type Table struct {
+	// The cards still held by every seat, keyed by direction
+	Hands map[int][]cards.Card
+
+	// The seat whose turn it is to play. Once a trick is complete, this is the seat that won the trick
+	// and therefore leads the next one
+	Current int
+
+	// The trick currently being played. A complete trick stays on the table until Collect is called so
+	// that the user interface has a chance to show it.
+	Trick Trick
+
+	// Every trick collected so far, in the order they were played
+	Tricks []Trick
+
+	// The number of tricks won by every seat, keyed by direction
+	TricksWon map[int]int
+
+	// The trump suit of the hand or cards.NoSuit when the game is played without trumps. Any card of the
+	// trump suit beats every card of the other suits, regardless of the suit that was led.
+	Trump cards.Suit
+
+	// An optional restriction applied on top of the follow suit rule. Refer to Restriction for more info
+	Restrict Restriction
+}
+
+// Provided constructor. The hands are copied so that the caller is free to reuse the passed slices. Pass
+// cards.NoSuit as the trump for games without trumps.
+func New(hands map[int][]cards.Card, leader int, trump cards.Suit) *Table {
+	table := &Table{
+		Hands:     make(map[int][]cards.Card),
+		Current:   leader,
+		Trick:     Trick{Leader: leader},
+		Tricks:    make([]Trick, 0, 13),
+		TricksWon: make(map[int]int),
+		Trump:     trump,
+	}
+	for _, seat := range directions.Order {
+		hand := make([]cards.Card, len(hands[seat]))
+		copy(hand, hands[seat])
+		table.Hands[seat] = hand
+		table.TricksWon[seat] = 0
+	}
+	return table
+}
+
+// Returns the suit that was led to the trick or cards.NoSuit if nothing has been played yet
+func (t *Trick) LeadSuit() cards.Suit {
+	if len(t.Plays) == 0 {
+		return cards.NoSuit
+	}
+	return t.Plays[0].Card.Suit
+}
+
+// Reports whether every seat has played to the trick
+func (t *Trick) Complete() bool {
+	return len(t.Plays) == len(directions.Order)
+}
+
+// Returns the card played by the given seat to this trick, or the zero card if the seat has not played yet
+func (t *Trick) CardOf(seat int) cards.Card {
+	for _, play := range t.Plays {
+		if play.Seat == seat {
+			return play.Card
+		}
+	}
+	return cards.Card{}
+}
+
+// Returns the play currently winning the trick: the highest trump if any trump was played, otherwise the
+// highest card of the suit that was led. Pass cards.NoSuit as the trump for games without trumps. The zero
+// Play is returned for an empty trick.
+func (t *Trick) Winner(trump cards.Suit) Play {
+	if len(t.Plays) == 0 {
+		return Play{}
+	}
+
+	best := t.Plays[0]
+	for _, play := range t.Plays[1:] {
+		if Beats(play.Card, best.Card, trump) {
+			best = play
+		}
+	}
+	return best
+}
+
+// Reports whether the challenger beats the card currently winning a trick. A card can only win by being a
+// higher card of the same suit or by trumping a card of a different suit.
+func Beats(challenger, winning cards.Card, trump cards.Suit) bool {
+	if challenger.Suit == winning.Suit {
+		return cards.CompareRank(challenger, winning) > 0
+	}
+	return trump != cards.NoSuit && challenger.Suit == trump
+}
+
+// Returns the cards the given seat may legally play right now. Nothing may be played when it is not the
+// seat's turn or when a complete trick is still waiting to be collected. Otherwise a seat must follow the
+// suit that was led if it can, and may play anything if it cannot or if it is leading.
+func (t *Table) LegalMoves(seat int) []cards.Card {
+	if seat != t.Current || t.Trick.Complete() {
+		return nil
+	}
+
+	hand := t.Hands[seat]
+	legal := make([]cards.Card, len(hand))
+	copy(legal, hand)
+	if lead := t.Trick.LeadSuit(); lead != cards.NoSuit {
+		if following := cards.OfSuit(hand, lead); len(following) > 0 {
+			legal = following
+		}
+	}
+
+	if t.Restrict != nil {
+		if restricted := t.Restrict(t, seat, legal); len(restricted) > 0 {
+			return restricted
+		}
+	}
+	return legal
+}
+
+// Reports whether the given seat may play the given card, returning an error describing the reason if not
+func (t *Table) CheckPlay(seat int, card cards.Card) error {
+	if t.Trick.Complete() {
+		return errors.New("play error: the trick must be collected before playing")
+	}
+	if seat != t.Current {
+		return errors.New(fmt.Sprintf("play error: it is not %s's turn", directions.String(seat)))
+	}
+	if !cards.Contains(t.Hands[seat], card) {
+		return errors.New(fmt.Sprintf("play error: %s does not hold %s", directions.String(seat), card.Name()))
+	}
+	if !cards.Contains(t.LegalMoves(seat), card) {
+		if lead := t.Trick.LeadSuit(); lead != cards.NoSuit && card.Suit != lead {
+			return errors.New(fmt.Sprintf("play error: %s must follow %s", directions.String(seat), lead))
+		}
+		return errors.New(fmt.Sprintf("play error: %s cannot be played right now", card.Name()))
+	}
+	return nil
+}
+
+// Reports whether the given card is a legal play for the seat
+func (t *Table) IsLegal(seat int, card cards.Card) bool {
+	return t.CheckPlay(seat, card) == nil
+}
+
+// Plays a card for the given seat after validating it. The turn moves on to the next seat in
+// directions.Order, unless the trick is now complete in which case it moves to the trick's winner.
+func (t *Table) Play(seat int, card cards.Card) error {
+	err := t.CheckPlay(seat, card)
+	if err != nil {
+		return err
+	}
+
+	t.Hands[seat] = cards.Remove(t.Hands[seat], card)
+	t.Trick.Plays = append(t.Trick.Plays, Play{Seat: seat, Card: card})
+
+	if t.Trick.Complete() {
+		t.Current = t.Trick.Winner(t.Trump).Seat
+	} else {
+		t.Current = directions.Next(seat)
+	}
+	return nil
+}
+
+// Reports whether the current trick is complete and waiting to be collected
+func (t *Table) TrickComplete() bool {
+	return t.Trick.Complete()
+}
+
+// Collects a complete trick, crediting it to its winner and starting a new trick led by the winner. Returns
+// the winning seat.
+func (t *Table) Collect() (int, error) {
+	if !t.Trick.Complete() {
+		return 0, errors.New("collect error: the trick is not complete")
+	}
+
+	winner := t.Trick.Winner(t.Trump).Seat
+	t.TricksWon[winner]++
+	t.Tricks = append(t.Tricks, t.Trick)
+	t.Trick = Trick{Leader: winner}
+	t.Current = winner
+	return winner, nil
+}
+
+// Returns the last collected trick, or nil if no trick has been collected yet
+func (t *Table) LastTrick() *Trick {
+	if len(t.Tricks) == 0 {
+		return nil
+	}
+	return &t.Tricks[len(t.Tricks)-1]
+}
+
+// Reports whether any card of the given suit has been played so far in the hand, including to the current
+// trick. Useful for rules such as "spades cannot be led until broken".
+func (t *Table) SuitPlayed(suit cards.Suit) bool {
+	for _, trick := range t.Tricks {
+		for _, play := range trick.Plays {
+			if play.Card.Suit == suit {
+				return true
+			}
+		}
+	}
+	for _, play := range t.Trick.Plays {
+		if play.Card.Suit == suit {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether every card of the hand has been played and collected
+func (t *Table) HandOver() bool {
+	if len(t.Trick.Plays) > 0 {
+		return false
+	}
+	for _, hand := range t.Hands {
+		if len(hand) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns a copy of the table that can be played on without affecting the original, for example by
+// computer players trying out moves
+func (t *Table) Clone() *Table {
+	clone := &Table{
+		Hands:     make(map[int][]cards.Card, len(t.Hands)),
+		Current:   t.Current,
+		Trick:     t.Trick.clone(),
+		Tricks:    make([]Trick, len(t.Tricks), cap(t.Tricks)),
+		TricksWon: make(map[int]int, len(t.TricksWon)),
+		Trump:     t.Trump,
+		Restrict:  t.Restrict,
+	}
+	for seat, hand := range t.Hands {
+		clone.Hands[seat] = append([]cards.Card(nil), hand...)
+	}
+	for i, trick := range t.Tricks {
+		clone.Tricks[i] = trick.clone()
+	}
+	for seat, won := range t.TricksWon {
+		clone.TricksWon[seat] = won
+	}
+	return clone
+}
+
+func (t Trick) clone() Trick {
+	plays := make([]Play, len(t.Plays), len(directions.Order))
+	copy(plays, t.Plays)
+	return Trick{Leader: t.Leader, Plays: plays}
+}
@@ -0,0 +1,191 @@
+package rules
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/utils/directions"
+	"strings"
+	"testing"
+)
+
+// Parses cards written like "h2 sQ c1", refer to cards.Parse
+func parse(t *testing.T, names string) []cards.Card {
+	hand, err := cards.ParseAll(strings.Fields(names)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hand
+}
+
+// Reports whether the two hands hold the same cards in the same order, nil being the same as empty
+func sameCards(a, b []cards.Card) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Deals the hands, in directions.Order, to a table led by North and plays the cards in turn
+func newTestTable(t *testing.T, hands [4]string, trump cards.Suit, played string) *Table {
+	dealt := make(map[int][]cards.Card)
+	for i, seat := range directions.Order {
+		dealt[seat] = parse(t, hands[i])
+	}
+	table := New(dealt, directions.North, trump)
+	for _, card := range parse(t, played) {
+		err := table.Play(table.Current, card)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return table
+}
+
+var testHands = [4]string{"h2 h9 s3", "hK d4 s1", "d5 d9 c2", "h5 hX c1"}
+
+// Leaves the spades out of the cards a seat may play
+func noSpades(t *Table, seat int, legal []cards.Card) []cards.Card {
+	var result []cards.Card
+	for _, card := range legal {
+		if card.Suit != cards.Spades {
+			result = append(result, card)
+		}
+	}
+	return result
+}
+
+func TestBeats(t *testing.T) {
+	tests := []struct {
+		challenger, winning string
+		trump               cards.Suit
+		beats               bool
+	}{
+		{"hK", "h9", cards.NoSuit, true},
+		{"h5", "h9", cards.NoSuit, false},
+		{"h1", "hK", cards.NoSuit, true},
+		{"s2", "h1", cards.NoSuit, false},
+		{"s2", "h1", cards.Spades, true},
+		{"sQ", "sK", cards.Spades, false},
+		{"d1", "h2", cards.Spades, false},
+		{"h1", "s2", cards.Spades, false},
+	}
+	for _, test := range tests {
+		challenger, winning := cards.MustParse(test.challenger), cards.MustParse(test.winning)
+		if Beats(challenger, winning, test.trump) != test.beats {
+			t.Errorf("%s on %s with %s trumps: expected beats to be %v", test.challenger, test.winning,
+				test.trump, test.beats)
+		}
+	}
+}
+
+func TestTrickWinner(t *testing.T) {
+	tests := []struct {
+		plays  string
+		trump  cards.Suit
+		winner int
+	}{
+		{"h2 hK h9 hX", cards.NoSuit, directions.East},
+		{"h2 s3 h9 hX", cards.NoSuit, directions.West},
+		{"h2 s3 h9 hX", cards.Spades, directions.East},
+		{"h2 s3 s9 hX", cards.Spades, directions.South},
+		{"s2 h1 c1 d1", cards.Hearts, directions.East},
+	}
+	for _, test := range tests {
+		trick := Trick{Leader: directions.North}
+		seat := directions.North
+		for _, card := range parse(t, test.plays) {
+			trick.Plays = append(trick.Plays, Play{Seat: seat, Card: card})
+			seat = directions.Next(seat)
+		}
+		if winner := trick.Winner(test.trump).Seat; winner != test.winner {
+			t.Errorf("%s with %s trumps: won by %s, expected %s", test.plays, test.trump,
+				directions.String(winner), directions.String(test.winner))
+		}
+	}
+}
+
+func TestLegalMoves(t *testing.T) {
+	tests := []struct {
+		name     string
+		played   string
+		seat     int
+		restrict Restriction
+		legal    string
+	}{
+		{"the leader plays anything", "", directions.North, nil, "h2 h9 s3"},
+		{"the suit led is followed", "h2", directions.East, nil, "hK"},
+		{"anything is played when void", "h2 hK", directions.South, nil, "d5 d9 c2"},
+		{"nothing is played out of turn", "", directions.East, nil, ""},
+		{"nothing is played on a complete trick", "h2 hK d5 h5", directions.East, nil, ""},
+		{"the restriction applies", "", directions.North, noSpades, "h2 h9"},
+		{"an empty restriction is ignored", "h2", directions.East, noSpades, "hK"},
+	}
+	for _, test := range tests {
+		table := newTestTable(t, testHands, cards.NoSuit, test.played)
+		table.Restrict = test.restrict
+		if legal := table.LegalMoves(test.seat); !sameCards(legal, parse(t, test.legal)) {
+			t.Errorf("%s: got %v, expected %s", test.name, legal, test.legal)
+		}
+	}
+}
+
+func TestCheckPlay(t *testing.T) {
+	tests := []struct {
+		name     string
+		played   string
+		seat     int
+		card     string
+		restrict Restriction
+		err      string
+	}{
+		{"a legal play", "", directions.North, "h9", nil, ""},
+		{"out of turn", "", directions.East, "hK", nil, "not East's turn"},
+		{"a card not held", "", directions.North, "d4", nil, "does not hold"},
+		{"not following suit", "h2", directions.East, "d4", nil, "must follow"},
+		{"on a complete trick", "h2 hK d5 h5", directions.East, "d4", nil, "must be collected"},
+		{"a restricted card", "", directions.North, "s3", noSpades, "cannot be played"},
+	}
+	for _, test := range tests {
+		table := newTestTable(t, testHands, cards.NoSuit, test.played)
+		table.Restrict = test.restrict
+		err := table.CheckPlay(test.seat, cards.MustParse(test.card))
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: unexpected error %q", test.name, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("%s: got %v, expected an error about %q", test.name, err, test.err)
+		}
+	}
+}
+
+func TestTheWinnerCollectsAndLeads(t *testing.T) {
+	table := newTestTable(t, [4]string{"h2", "hK", "s3", "s9"}, cards.Spades, "h2 hK")
+	if _, err := table.Collect(); err == nil {
+		t.Fatal("an incomplete trick was collected")
+	}
+
+	for _, card := range parse(t, "s3 s9") {
+		err := table.Play(table.Current, card)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if table.Current != directions.West {
+		t.Fatalf("%s is to collect, expected West", directions.String(table.Current))
+	}
+
+	winner, err := table.Collect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if winner != directions.West || table.TricksWon[directions.West] != 1 || table.Trick.Leader != directions.West {
+		t.Fatalf("the trick was credited to %s", directions.String(winner))
+	}
+	if len(table.Tricks) != 1 || !table.HandOver() {
+		t.Fatal("the hand is not over once every card was collected")
+	}
+}
@@ -0,0 +1,75 @@
+package scenes
+
+import (
+	"CardGameGo/src/components/buttons/imagebutton"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/screens"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The bids of the hand being played, shown until every seat bid
+type BiddingScene struct {
+	e          *engine.Engine
+	session    *Session
+	homeButton *imagebutton.ImageButton
+}
+
+// Provided constructor
+func NewBiddingScene(e *engine.Engine, session *Session) *BiddingScene {
+	return &BiddingScene{
+		e:          e,
+		session:    session,
+		homeButton: newHomeButton(e),
+	}
+}
+
+func (b *BiddingScene) OnEnter() error {
+	eventManager := b.e.Event[screens.BiddingScreen]
+	if b.session.BiddingUi != nil {
+		b.session.BiddingUi.Register(eventManager)
+	}
+	eventManager.RegisterEvent(b.homeButton)
+	return nil
+}
+
+func (b *BiddingScene) OnExit() error {
+	return nil
+}
+
+// Moves the game forward, back to the table once the bidding is over
+func (b *BiddingScene) Update(dt time.Duration) error {
+	if b.session.BiddingUi == nil || b.session.BiddingUi.Done() {
+		b.e.CurrentScreen = screens.GameScreen
+		return nil
+	}
+	err := b.session.GameUi.Update()
+	if err != nil {
+		return err
+	}
+	b.session.wake(b.e)
+	return nil
+}
+
+func (b *BiddingScene) Draw() error {
+	drawBackground(b.e)
+	err := drawHomeButton(b.e, b.homeButton)
+	if err != nil {
+		return err
+	}
+
+	if b.session.BiddingUi == nil {
+		return nil
+	}
+	w, h := b.e.Window.GetSize()
+	return b.session.BiddingUi.Draw(w, h, b.e.Renderer)
+}
+
+// The back button pauses the game
+func (b *BiddingScene) HandleEvent(event sdl.Event) error {
+	handled, err := handleEvent(b.e, screens.BiddingScreen, event)
+	if !handled && isBack(event) {
+		return b.e.PushScene(screens.PauseScreen)
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+package scenes
+
+import (
+	"CardGameGo/src/components/buttons/imagebutton"
+	"CardGameGo/src/components/textinput"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/game"
+	"CardGameGo/src/screens"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The table of the game being played. The scoreboard and the pause menu are pushed on top of it as overlays
+type GameScene struct {
+	e          *engine.Engine
+	session    *Session
+	homeButton *imagebutton.ImageButton
+}
+
+// Provided constructor
+func NewGameScene(e *engine.Engine, session *Session) *GameScene {
+	return &GameScene{
+		e:          e,
+		session:    session,
+		homeButton: newHomeButton(e),
+	}
+}
+
+// Registers the table and then the home button, so that the home button takes precedence
+func (g *GameScene) OnEnter() error {
+	eventManager := g.e.Event[screens.GameScreen]
+	if g.session.GameUi != nil {
+		g.session.GameUi.Register(eventManager)
+	}
+	eventManager.RegisterEvent(g.homeButton)
+	return nil
+}
+
+// The chat loses the keyboard
+func (g *GameScene) OnExit() error {
+	textinput.Blur()
+	return nil
+}
+
+// Moves the game forward. The game is set up in the lobby, which is shown again whenever the game is not
+// started, and the hands of variants with a bidding phase start on the bidding screen
+func (g *GameScene) Update(dt time.Duration) error {
+	ui := g.session.GameUi
+	if ui == nil || !ui.GameStarted {
+		g.e.CurrentScreen = screens.LobbyScreen
+		return nil
+	}
+
+	err := ui.Update()
+	if err != nil {
+		return err
+	}
+	g.session.wake(g.e)
+
+	if ui.Phase() == game.Bidding {
+		g.e.CurrentScreen = screens.BiddingScreen
+		return nil
+	}
+	if ui.ShowScoreboard && !g.e.Shows(screens.ScoreboardScreen) {
+		return g.e.PushScene(screens.ScoreboardScreen)
+	}
+	return nil
+}
+
+func (g *GameScene) Draw() error {
+	drawBackground(g.e)
+	err := drawHomeButton(g.e, g.homeButton)
+	if err != nil {
+		return err
+	}
+
+	ui := g.session.GameUi
+	if ui == nil || !ui.GameStarted {
+		return nil
+	}
+	w, h := g.e.Window.GetSize()
+	return ui.Draw(w, h, g.e.Renderer)
+}
+
+// The back button pauses the game
+func (g *GameScene) HandleEvent(event sdl.Event) error {
+	handled, err := handleEvent(g.e, screens.GameScreen, event)
+	if !handled && isBack(event) {
+		return g.e.PushScene(screens.PauseScreen)
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package scenes
+
+import (
+	"CardGameGo/src/components/buttons/imagebutton"
+	"CardGameGo/src/components/textinput"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/network"
+	"CardGameGo/src/screens"
+	"CardGameGo/src/variants"
+	"fmt"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The seats of the game about to start. A new game is set up every time the lobby is entered from the New
+// Game or Host Game buttons, refer to Session.StartNewGame
+type LobbyScene struct {
+	e          *engine.Engine
+	session    *Session
+	homeButton *imagebutton.ImageButton
+}
+
+// Provided constructor
+func NewLobbyScene(e *engine.Engine, session *Session) *LobbyScene {
+	return &LobbyScene{
+		e:          e,
+		session:    session,
+		homeButton: newHomeButton(e),
+	}
+}
+
+func (l *LobbyScene) OnEnter() error {
+	if l.session.StartNewGame {
+		err := l.setUp()
+		if err != nil {
+			return err
+		}
+	}
+
+	eventManager := l.e.Event[screens.LobbyScreen]
+	if l.session.LobbyUi != nil {
+		l.session.LobbyUi.Register(eventManager)
+	}
+	eventManager.RegisterEvent(l.homeButton)
+	return nil
+}
+
+// Sets up a new game of the variant selected, hosted for other devices or not
+func (l *LobbyScene) setUp() error {
+	session := l.session
+	err := session.InitGameUi(l.e)
+	if err != nil {
+		return err
+	}
+	session.StartNewGame = false
+	ui := session.GameUi
+
+	// A game joined on another device is left to set up a game of this device
+	err = ui.LeaveGame()
+	if err != nil {
+		fmt.Printf("ignoring leave error %q\n", err)
+	}
+	ui.SetVariant(variants.All[session.Variant])
+
+	if session.HostGame {
+		err = ui.HostGame(fmt.Sprintf(":%d", network.DefaultPort))
+	} else {
+		err = ui.StopHosting()
+	}
+	if err != nil {
+		fmt.Printf("ignoring host error %q\n", err)
+	}
+
+	session.SetBots()
+	return ui.OpenLobby()
+}
+
+// The name input loses the keyboard
+func (l *LobbyScene) OnExit() error {
+	textinput.Blur()
+	return nil
+}
+
+// Moves to the table once the game started
+func (l *LobbyScene) Update(dt time.Duration) error {
+	session := l.session
+	if session.GameUi == nil {
+		l.e.CurrentScreen = screens.MainScreen
+		return nil
+	}
+
+	err := session.GameUi.Update()
+	if err != nil {
+		return err
+	}
+	session.wake(l.e)
+	if session.GameUi.DevicePlayer.Name != "" {
+		session.PlayerName = session.GameUi.DevicePlayer.Name
+	}
+
+	if session.LobbyUi.Done() {
+		l.e.CurrentScreen = screens.GameScreen
+	}
+	return nil
+}
+
+func (l *LobbyScene) Draw() error {
+	drawBackground(l.e)
+	err := drawHomeButton(l.e, l.homeButton)
+	if err != nil {
+		return err
+	}
+
+	if l.session.LobbyUi == nil {
+		return nil
+	}
+	w, h := l.e.Window.GetSize()
+	return l.session.LobbyUi.Draw(w, h, l.e.Renderer)
+}
+
+// The back button leaves the application
+func (l *LobbyScene) HandleEvent(event sdl.Event) error {
+	handled, err := handleEvent(l.e, screens.LobbyScreen, event)
+	if !handled && isBack(event) {
+		l.e.Quit()
+	}
+	return err
+}
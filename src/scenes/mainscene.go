@@ -0,0 +1,328 @@
+package scenes
+
+import (
+	"CardGameGo/src/bots"
+	"CardGameGo/src/components/buttons/rectbutton"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/managers/gamemanager"
+	"CardGameGo/src/network"
+	"CardGameGo/src/screens"
+	"CardGameGo/src/utils"
+	"CardGameGo/src/variants"
+	"fmt"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The number of discovered games listed on the main screen
+const maxListedGames = 3
+
+// The first screen of the application, starting, joining or resuming games
+type MainScene struct {
+	e       *engine.Engine
+	session *Session
+
+	newGameButton    *rectbutton.RectangularButton
+	hostGameButton   *rectbutton.RectangularButton
+	variantButton    *rectbutton.RectangularButton
+	difficultyButton *rectbutton.RectangularButton
+	resumeButton     *rectbutton.RectangularButton
+	settingsButton   *rectbutton.RectangularButton
+	replaysButton    *rectbutton.RectangularButton
+
+	// The buttons joining the games discovered, and the games they join. Refer to listGames
+	joinButtons [maxListedGames]*rectbutton.RectangularButton
+	listedGames []network.DiscoveredGame
+
+	// Listens to the games advertised on the local network while the main screen is shown. Nil when the
+	// discovery port could not be listened to, in which case no game is listed
+	browser *network.Browser
+
+	// Reports whether a game was saved when the main screen was entered, refer to resume
+	hasSave bool
+}
+
+// Provided constructor
+func NewMainScene(e *engine.Engine, session *Session) *MainScene {
+	m := &MainScene{
+		e:       e,
+		session: session,
+	}
+
+	color := utils.GRAY
+	font, _ := e.Font.GetFont("universalfruitcake", 20)
+
+	m.newGameButton = rectbutton.New("New Game", 350, 75, color, font)
+	m.newGameButton.CallBack = func(...interface{}) error {
+		session.StartNewGame = true
+		session.HostGame = false
+		e.CurrentScreen = screens.LobbyScreen
+		return nil
+	}
+
+	// Starts a new game that other devices can join
+	m.hostGameButton = rectbutton.New("Host Game", 350, 75, color, font)
+	m.hostGameButton.CallBack = func(...interface{}) error {
+		session.StartNewGame = true
+		session.HostGame = true
+		e.CurrentScreen = screens.LobbyScreen
+		return nil
+	}
+
+	// Every click selects the next game of variants.All
+	m.variantButton = rectbutton.New("", 350, 75, color, font)
+	m.variantButton.CallBack = func(...interface{}) error {
+		session.Variant = (session.Variant + 1) % len(variants.All)
+		return nil
+	}
+
+	// Every click selects the next difficulty of bots.Difficulties
+	m.difficultyButton = rectbutton.New("", 350, 75, color, font)
+	m.difficultyButton.CallBack = func(...interface{}) error {
+		session.Difficulty = (session.Difficulty + 1) % len(bots.Difficulties)
+		return nil
+	}
+
+	m.resumeButton = rectbutton.New("Resume Game", 350, 75, utils.GREEN, font)
+	m.resumeButton.CallBack = func(...interface{}) error {
+		return m.resume()
+	}
+
+	m.settingsButton = rectbutton.New("Settings Button", 350, 75, color, font)
+	m.settingsButton.CallBack = func(...interface{}) error {
+		e.CurrentScreen = screens.SettingsScreen
+		return nil
+	}
+
+	// Replays the hands recorded on this device
+	m.replaysButton = rectbutton.New("Replays", 350, 75, color, font)
+	m.replaysButton.CallBack = func(...interface{}) error {
+		err := session.InitGameUi(e)
+		if err != nil {
+			return err
+		}
+		e.CurrentScreen = screens.ReplayScreen
+		return session.ReplayUi.Open()
+	}
+
+	for i := range m.joinButtons {
+		index := i
+		m.joinButtons[i] = rectbutton.New("", 350, 75, color, font)
+		m.joinButtons[i].CallBack = func(...interface{}) error {
+			if index >= len(m.listedGames) {
+				return nil
+			}
+			return m.join(m.listedGames[index])
+		}
+	}
+
+	return m
+}
+
+func (m *MainScene) OnEnter() error {
+	eventManager := m.e.Event[screens.MainScreen]
+	for _, button := range []*rectbutton.RectangularButton{m.newGameButton, m.hostGameButton, m.variantButton,
+		m.difficultyButton, m.resumeButton, m.settingsButton, m.replaysButton} {
+
+		eventManager.RegisterEvent(button)
+	}
+	for _, button := range m.joinButtons {
+		eventManager.RegisterEvent(button)
+	}
+
+	m.hasSave = gamemanager.HasSave(m.session.SavePath)
+
+	browser, err := network.NewBrowser(network.DiscoveryPort)
+	if err != nil {
+		fmt.Printf("ignoring discovery error %q\n", err)
+		return nil
+	}
+	m.browser = browser
+	return nil
+}
+
+// Stops listening to the games advertised, the screen is not shown anymore
+func (m *MainScene) OnExit() error {
+	m.listedGames = m.listedGames[:0]
+	if m.browser == nil {
+		return nil
+	}
+	err := m.browser.Close()
+	if err != nil {
+		fmt.Printf("ignoring discovery error %q\n", err)
+	}
+	m.browser = nil
+	return nil
+}
+
+// Lists the games hosted on the local network, leaving out the game of this device
+func (m *MainScene) Update(dt time.Duration) error {
+	if m.browser == nil {
+		return nil
+	}
+
+	m.listedGames = m.listedGames[:0]
+	for _, discovered := range m.browser.Games() {
+		if len(m.listedGames) == maxListedGames {
+			break
+		}
+		if discovered.GameId == m.session.GameId || !discovered.Compatible() {
+			continue
+		}
+		m.listedGames = append(m.listedGames, discovered)
+	}
+	return nil
+}
+
+func (m *MainScene) Draw() error {
+	e := m.e
+	_ = e.Renderer.Clear()
+	_ = e.Renderer.SetDrawColor(66, 152, 66, 1)
+	_ = e.Renderer.FillRect(nil)
+
+	//Insert Card Image
+	image := e.Image.Images["cardicon"]
+	w, h := e.Window.GetSize()
+	err := e.Renderer.Copy(image, nil, utils.CenterTexture(image, w, h/2))
+	if err != nil {
+		return err
+	}
+
+	// Insert New Game Button
+	cenX, newGameButtonY := utils.GetCenterCoordinates(m.newGameButton.Width, m.newGameButton.Height, w, h)
+	err = m.newGameButton.Draw(cenX, newGameButtonY, e.Renderer)
+	if err != nil {
+		return err
+	}
+
+	// Insert Host Game Button
+	err = m.hostGameButton.Draw(cenX, newGameButtonY+100, e.Renderer)
+	if err != nil {
+		return err
+	}
+
+	// Insert Variant Button
+	m.variantButton.BtnText = "Game: " + variants.All[m.session.Variant].Name()
+	err = m.variantButton.Draw(cenX, newGameButtonY-100, e.Renderer)
+	if err != nil {
+		return err
+	}
+
+	// Insert Difficulty Button
+	m.difficultyButton.BtnText = "Bots: " + bots.Difficulties[m.session.Difficulty].String()
+	err = m.difficultyButton.Draw(cenX, newGameButtonY-200, e.Renderer)
+	if err != nil {
+		return err
+	}
+
+	// Insert Resume Game Button, only drawn when a game was saved. It is hidden off the screen otherwise
+	if m.hasSave {
+		err = m.resumeButton.Draw(cenX, newGameButtonY-300, e.Renderer)
+	} else {
+		err = m.resumeButton.Draw(w, h, e.Renderer)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Insert Settings Button
+	err = m.settingsButton.Draw(cenX, newGameButtonY+200, e.Renderer)
+	if err != nil {
+		return err
+	}
+
+	// Insert Replays Button
+	err = m.replaysButton.Draw(cenX, newGameButtonY+300, e.Renderer)
+	if err != nil {
+		return err
+	}
+
+	return m.drawListedGames(cenX, newGameButtonY+400)
+}
+
+// Lists the games hosted on the local network below the buttons, one button per game. Tapping a game joins
+// it right away, or watches it when every seat is taken. The buttons left over are hidden off the screen.
+func (m *MainScene) drawListedGames(x, y int32) error {
+	w, h := m.e.Window.GetSize()
+	for i, joinButton := range m.joinButtons {
+		var err error
+		if i < len(m.listedGames) {
+			discovered := m.listedGames[i]
+			joinButton.BtnText = fmt.Sprintf("Join %s: %s (%d free)", discovered.Host, discovered.Variant,
+				discovered.FreeSeats)
+			if discovered.FreeSeats == 0 {
+				joinButton.BtnText = fmt.Sprintf("Watch %s: %s", discovered.Host, discovered.Variant)
+			}
+			err = joinButton.Draw(x, y+int32(i)*100, m.e.Renderer)
+		} else {
+			err = joinButton.Draw(w, h, m.e.Renderer)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The back button leaves the application
+func (m *MainScene) HandleEvent(event sdl.Event) error {
+	handled, err := handleEvent(m.e, screens.MainScreen, event)
+	if !handled && isBack(event) {
+		m.e.Quit()
+	}
+	return err
+}
+
+// Joins the game discovered, or watches it when every seat is taken
+func (m *MainScene) join(discovered network.DiscoveredGame) error {
+	session := m.session
+	spectate := discovered.FreeSeats == 0
+
+	var client *network.Client
+	var err error
+	if spectate {
+		client, err = network.Watch(discovered.Address, session.PlayerName)
+	} else {
+		client, err = network.Dial(discovered.Address, session.PlayerName, session.PlayerId)
+	}
+	if err != nil {
+		fmt.Printf("ignoring join error %q\n", err)
+		return nil
+	}
+	err = session.InitGameUi(m.e)
+	if err != nil {
+		return err
+	}
+	session.GameUi.JoinGame(client, session.PlayerName)
+	session.StartNewGame = false
+	m.e.CurrentScreen = screens.LobbyScreen
+	if spectate {
+		m.e.CurrentScreen = screens.SpectatorScreen
+	}
+	return nil
+}
+
+// Resumes the game saved, which is dropped if it cannot be resumed
+func (m *MainScene) resume() error {
+	session := m.session
+	saved, err := gamemanager.LoadGame(session.SavePath)
+	if err != nil {
+		fmt.Printf("ignoring resume error %q\n", err)
+		m.hasSave = false
+		return gamemanager.RemoveSave(session.SavePath)
+	}
+	err = session.InitGameUi(m.e)
+	if err != nil {
+		return err
+	}
+	err = session.GameUi.Resume(saved)
+	if err != nil {
+		fmt.Printf("ignoring resume error %q\n", err)
+		m.hasSave = false
+		return gamemanager.RemoveSave(session.SavePath)
+	}
+	session.SetBots()
+	session.StartNewGame = false
+	m.e.CurrentScreen = screens.GameScreen
+	return nil
+}
@@ -0,0 +1,80 @@
+package scenes
+
+import (
+	"CardGameGo/src/components/buttons/rectbutton"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/screens"
+	"CardGameGo/src/utils"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The menu pushed on top of the table by the back button. The other players do not wait for the device
+// player, so the game carries on underneath while the menu is shown
+type PauseScene struct {
+	e            *engine.Engine
+	resumeButton *rectbutton.RectangularButton
+	leaveButton  *rectbutton.RectangularButton
+}
+
+// Provided constructor
+func NewPauseScene(e *engine.Engine) *PauseScene {
+	p := &PauseScene{
+		e: e,
+	}
+	font, _ := e.Font.GetFont("universalfruitcake", 20)
+
+	p.resumeButton = rectbutton.New("Resume", 350, 75, utils.GREEN, font)
+	p.resumeButton.CallBack = func(i ...interface{}) error {
+		return e.PopScene()
+	}
+
+	p.leaveButton = rectbutton.New("Main Menu", 350, 75, utils.GREEN, font)
+	p.leaveButton.CallBack = func(i ...interface{}) error {
+		e.CurrentScreen = screens.MainScreen
+		return nil
+	}
+	return p
+}
+
+func (p *PauseScene) OnEnter() error {
+	eventManager := p.e.Event[screens.PauseScreen]
+	eventManager.RegisterEvent(p.resumeButton)
+	eventManager.RegisterEvent(p.leaveButton)
+	return nil
+}
+
+func (p *PauseScene) OnExit() error {
+	return nil
+}
+
+func (p *PauseScene) Update(dt time.Duration) error {
+	return nil
+}
+
+// Draws the buttons on a panel in the middle of the table
+func (p *PauseScene) Draw() error {
+	w, h := p.e.Window.GetSize()
+	panel := sdl.Rect{X: (w - 450) / 2, Y: h/2 - 125, W: 450, H: 250}
+	_ = p.e.Renderer.SetDrawColor(utils.GRAY.R, utils.GRAY.G, utils.GRAY.B, 255)
+	err := p.e.Renderer.FillRect(&panel)
+	if err != nil {
+		return err
+	}
+
+	x := (w - p.resumeButton.Width) / 2
+	err = p.resumeButton.Draw(x, panel.Y+30, p.e.Renderer)
+	if err != nil {
+		return err
+	}
+	return p.leaveButton.Draw(x, panel.Y+panel.H-p.leaveButton.Height-30, p.e.Renderer)
+}
+
+// The back button resumes the game
+func (p *PauseScene) HandleEvent(event sdl.Event) error {
+	handled, err := handleEvent(p.e, screens.PauseScreen, event)
+	if !handled && isBack(event) {
+		return p.e.PopScene()
+	}
+	return err
+}
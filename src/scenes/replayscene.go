@@ -0,0 +1,68 @@
+package scenes
+
+import (
+	"CardGameGo/src/components/buttons/imagebutton"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/screens"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The hands recorded on this device, refer to gamemanager.ReplayUiManager
+type ReplayScene struct {
+	e          *engine.Engine
+	session    *Session
+	homeButton *imagebutton.ImageButton
+}
+
+// Provided constructor
+func NewReplayScene(e *engine.Engine, session *Session) *ReplayScene {
+	return &ReplayScene{
+		e:          e,
+		session:    session,
+		homeButton: newHomeButton(e),
+	}
+}
+
+func (r *ReplayScene) OnEnter() error {
+	eventManager := r.e.Event[screens.ReplayScreen]
+	if r.session.ReplayUi != nil {
+		r.session.ReplayUi.Register(eventManager)
+	}
+	eventManager.RegisterEvent(r.homeButton)
+	return nil
+}
+
+func (r *ReplayScene) OnExit() error {
+	return nil
+}
+
+func (r *ReplayScene) Update(dt time.Duration) error {
+	if r.session.ReplayUi == nil {
+		r.e.CurrentScreen = screens.MainScreen
+	}
+	return nil
+}
+
+func (r *ReplayScene) Draw() error {
+	drawBackground(r.e)
+	err := drawHomeButton(r.e, r.homeButton)
+	if err != nil {
+		return err
+	}
+
+	if r.session.ReplayUi == nil {
+		return nil
+	}
+	w, h := r.e.Window.GetSize()
+	return r.session.ReplayUi.Draw(w, h, r.e.Renderer)
+}
+
+// The back button leaves the application
+func (r *ReplayScene) HandleEvent(event sdl.Event) error {
+	handled, err := handleEvent(r.e, screens.ReplayScreen, event)
+	if !handled && isBack(event) {
+		r.e.Quit()
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+// The scenes drawing the screens of the application, refer to engine.Scene. Every scene keeps its own
+// widgets, creates them once when it is constructed and registers them with the event manager of its screen
+// when it is entered. The state shared by the scenes, such as the game being played, is kept by the Session.
+package scenes
+
+import (
+	"CardGameGo/src/components/buttons/imagebutton"
+	"CardGameGo/src/components/textinput"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/screens"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Registers the scene of every screen with the engine
+func Register(e *engine.Engine, session *Session) {
+	e.RegisterScene(screens.MainScreen, NewMainScene(e, session))
+	e.RegisterScene(screens.GameScreen, NewGameScene(e, session))
+	e.RegisterScene(screens.SettingsScreen, NewSettingsScene(e))
+	e.RegisterScene(screens.BiddingScreen, NewBiddingScene(e, session))
+	e.RegisterScene(screens.LobbyScreen, NewLobbyScene(e, session))
+	e.RegisterScene(screens.SpectatorScreen, NewSpectatorScene(e, session))
+	e.RegisterScene(screens.ReplayScreen, NewReplayScene(e, session))
+	e.RegisterScene(screens.PauseScreen, NewPauseScene(e))
+	e.RegisterScene(screens.ScoreboardScreen, NewScoreboardScene(e, session))
+}
+
+// Handles the events every scene reacts to the same way: the mouse and the fingers are passed to the event
+// manager of the screen, firing and highlighting its widgets, while the text typed and the keys go to the focused text
+// input. Reports whether the event was handled, the scene handles the other events itself
+func handleEvent(e *engine.Engine, screen int, event sdl.Event) (bool, error) {
+	switch t := event.(type) {
+	// The touches are handled as such, so the mouse events SDL emulates for them are left out
+	case *sdl.MouseButtonEvent:
+		if t.Which == sdl.TOUCH_MOUSEID {
+			return true, nil
+		}
+		return true, e.Event[screen].ProcessClickEvents(t)
+
+	case *sdl.MouseMotionEvent:
+		if t.Which != sdl.TOUCH_MOUSEID {
+			e.Event[screen].ProcessMotionEvents(t)
+		}
+		return true, nil
+
+	case *sdl.TouchFingerEvent:
+		w, h := e.Window.GetSize()
+		return true, e.Event[screen].ProcessTouchEvents(t, w, h)
+
+	case *sdl.TextInputEvent:
+		if input := textinput.Focused(); input != nil {
+			input.HandleText(t.GetText())
+		}
+		return true, nil
+
+	case *sdl.KeyboardEvent:
+		// While a text input has the keyboard, the keys edit its text instead
+		if input := textinput.Focused(); input != nil {
+			return true, input.HandleKey(t)
+		}
+	}
+	return false, nil
+}
+
+// Reports whether the event is the escape key, or the back button on Android, being pressed
+func isBack(event sdl.Event) bool {
+	t, ok := event.(*sdl.KeyboardEvent)
+	return ok && t.Type == sdl.KEYDOWN &&
+		(t.Keysym.Scancode == sdl.SCANCODE_ESCAPE || t.Keysym.Scancode == sdl.SCANCODE_AC_BACK)
+}
+
+// Returns the button in the top right corner of a screen leading back to the main screen
+func newHomeButton(e *engine.Engine) *imagebutton.ImageButton {
+	button := imagebutton.New(e.Image.Images["home"])
+	button.CallBack = func(i ...interface{}) error {
+		e.CurrentScreen = screens.MainScreen
+		return nil
+	}
+	return button
+}
+
+// Draws the home button in the top right corner of the window
+func drawHomeButton(e *engine.Engine, button *imagebutton.ImageButton) error {
+	w, _ := e.Window.GetSize()
+	return button.Draw(w-button.Width-10, button.Height, e.Renderer)
+}
+
+// Fills the window with the background color of the screens showing a game
+func drawBackground(e *engine.Engine) {
+	_ = e.Renderer.Clear()
+	_ = e.Renderer.SetDrawColor(168, 235, 254, 255)
+	_ = e.Renderer.FillRect(nil)
+}
@@ -0,0 +1,55 @@
+package scenes
+
+import (
+	"CardGameGo/src/engine"
+	"CardGameGo/src/screens"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The scoreboard of the match, pushed on top of the table whenever GameUiManager.ShowScoreboard is set and
+// popped once it is unset again
+type ScoreboardScene struct {
+	e       *engine.Engine
+	session *Session
+}
+
+// Provided constructor
+func NewScoreboardScene(e *engine.Engine, session *Session) *ScoreboardScene {
+	return &ScoreboardScene{
+		e:       e,
+		session: session,
+	}
+}
+
+func (s *ScoreboardScene) OnEnter() error {
+	s.session.GameUi.RegisterScoreboard(s.e.Event[screens.ScoreboardScreen])
+	return nil
+}
+
+func (s *ScoreboardScene) OnExit() error {
+	return nil
+}
+
+// Pops the scoreboard once it was closed. While the pause menu is shown on top of it, the scoreboard waits
+// for the pause menu to be popped first
+func (s *ScoreboardScene) Update(dt time.Duration) error {
+	if !s.session.GameUi.ShowScoreboard && s.e.TopScreen() == screens.ScoreboardScreen {
+		return s.e.PopScene()
+	}
+	return nil
+}
+
+func (s *ScoreboardScene) Draw() error {
+	w, h := s.e.Window.GetSize()
+	return s.session.GameUi.DrawScoreboard(w, h, s.e.Renderer)
+}
+
+// The back button closes the scoreboard
+func (s *ScoreboardScene) HandleEvent(event sdl.Event) error {
+	handled, err := handleEvent(s.e, screens.ScoreboardScreen, event)
+	if !handled && isBack(event) {
+		s.session.GameUi.ShowScoreboard = false
+	}
+	return err
+}
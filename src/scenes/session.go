@@ -0,0 +1,132 @@
+package scenes
+
+import (
+	"CardGameGo/src/bots"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/managers/gamemanager"
+	"CardGameGo/src/managers/interfaces"
+	"CardGameGo/src/network"
+	"CardGameGo/src/screens"
+	"CardGameGo/src/utils"
+	"CardGameGo/src/variants"
+	"time"
+)
+
+// The state shared by the scenes: the choices made on the main screen, who the player of this device is and
+// the game managers, created the first time a game is started or joined
+type Session struct {
+	GameUi      *gamemanager.GameUiManager
+	BiddingUi   *gamemanager.BiddingUiManager
+	LobbyUi     *gamemanager.LobbyUiManager
+	SpectatorUi *gamemanager.SpectatorUiManager
+	ReplayUi    *gamemanager.ReplayUiManager
+
+	// Reports whether the lobby sets up a new game the next time it is shown, rather than showing the game
+	// joined or resumed
+	StartNewGame bool
+
+	// Reports whether the game started by the New Game button is hosted for other devices to join
+	HostGame bool
+
+	// The index in variants.All of the game that is started by the New Game button
+	Variant int
+
+	// The index in bots.Difficulties of the bots playing the empty seats
+	Difficulty int
+
+	// The id of the games started on this device, used to leave its own game out of the games discovered
+	GameId string
+
+	// The name other devices see for the player of this device
+	PlayerName string
+
+	// Identifies the player of this device to the hosts of the games it joins, so that it gets its seat back
+	// after losing the connection
+	PlayerId string
+
+	// The file the game of this device is saved to, in the writable directory of the application. Empty when
+	// the directory is not available, in which case the game is never saved
+	SavePath string
+
+	// The directory every finished hand is recorded to, next to SavePath. Empty when the game is never saved
+	RecordDir string
+
+	// The last change of the table the engine was woken up for, refer to wake
+	lastChange time.Time
+}
+
+// Provided constructor
+func NewSession(savePath, recordDir string) *Session {
+	return &Session{
+		StartNewGame: true,
+		GameId:       network.NewGameId(),
+		PlayerName:   "Player",
+		PlayerId:     network.NewPlayerId(),
+		SavePath:     savePath,
+		RecordDir:    recordDir,
+	}
+}
+
+// Creates the game managers the first time a game is started or joined
+func (s *Session) InitGameUi(e *engine.Engine) error {
+	if s.GameUi != nil {
+		return nil
+	}
+
+	hostPlayer := &interfaces.Player{Name: s.PlayerName, Id: s.PlayerId, Direction: utils.East}
+	player1 := &interfaces.Player{Direction: utils.North}
+	player2 := &interfaces.Player{Direction: utils.South}
+	player3 := &interfaces.Player{Direction: utils.West}
+	players := map[*interfaces.Player]bool{
+		hostPlayer: true,
+		player1:    true,
+		player2:    true,
+		player3:    true,
+	}
+
+	context := interfaces.GameContext{
+		GameId:  s.GameId,
+		Players: players,
+		Host:    hostPlayer,
+		Variant: variants.All[s.Variant],
+	}
+
+	s.GameUi = gamemanager.New(hostPlayer, context)
+	err := s.GameUi.SetCurrentPlayer(hostPlayer)
+	if err != nil {
+		return err
+	}
+	s.GameUi.SavePath = s.SavePath
+	s.GameUi.RecordDir = s.RecordDir
+	s.GameUi.Init(e.Image, e.Event[screens.GameScreen], e.Font, e.Renderer)
+	s.BiddingUi = gamemanager.NewBidding(s.GameUi)
+	s.BiddingUi.Init(e.Event[screens.BiddingScreen], e.Font)
+	s.LobbyUi = gamemanager.NewLobby(s.GameUi)
+	s.LobbyUi.Init(e.Event[screens.LobbyScreen], e.Font)
+	s.SpectatorUi = gamemanager.NewSpectator(s.GameUi)
+	s.SpectatorUi.Init(e.Event[screens.SpectatorScreen], e.Font)
+	s.ReplayUi = gamemanager.NewReplay(s.RecordDir)
+	s.ReplayUi.Init(e.Event[screens.ReplayScreen], e.Font)
+	return nil
+}
+
+// Lets a bot of the selected difficulty play every seat without a person
+func (s *Session) SetBots() {
+	for player := range s.GameUi.Players {
+		if player == s.GameUi.DevicePlayer {
+			s.GameUi.SetBot(player.Direction, nil)
+		} else {
+			s.GameUi.SetBot(player.Direction, bots.New(bots.Difficulties[s.Difficulty]))
+		}
+	}
+}
+
+// Wakes the engine up when the table changed without any input, for example because a bot played, so that
+// the change is drawn at full frame rate. Refer to engine.Engine.Wake
+func (s *Session) wake(e *engine.Engine) {
+	if s.GameUi == nil || !s.GameUi.LastChange().After(s.lastChange) {
+		return
+	}
+	s.lastChange = s.GameUi.LastChange()
+	e.Wake()
+}
@@ -0,0 +1,53 @@
+package scenes
+
+import (
+	"CardGameGo/src/components/buttons/imagebutton"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/screens"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The settings of the application. Only the home button for now
+type SettingsScene struct {
+	e          *engine.Engine
+	homeButton *imagebutton.ImageButton
+}
+
+// Provided constructor
+func NewSettingsScene(e *engine.Engine) *SettingsScene {
+	return &SettingsScene{
+		e:          e,
+		homeButton: newHomeButton(e),
+	}
+}
+
+func (s *SettingsScene) OnEnter() error {
+	s.e.Event[screens.SettingsScreen].RegisterEvent(s.homeButton)
+	return nil
+}
+
+func (s *SettingsScene) OnExit() error {
+	return nil
+}
+
+func (s *SettingsScene) Update(dt time.Duration) error {
+	return nil
+}
+
+func (s *SettingsScene) Draw() error {
+	_ = s.e.Renderer.Clear()
+	_ = s.e.Renderer.SetDrawColor(255, 250, 205, 255)
+	_ = s.e.Renderer.FillRect(nil)
+
+	return drawHomeButton(s.e, s.homeButton)
+}
+
+// The back button leaves the application
+func (s *SettingsScene) HandleEvent(event sdl.Event) error {
+	handled, err := handleEvent(s.e, screens.SettingsScreen, event)
+	if !handled && isBack(event) {
+		s.e.Quit()
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+package scenes
+
+import (
+	"CardGameGo/src/components/buttons/imagebutton"
+	"CardGameGo/src/components/textinput"
+	"CardGameGo/src/engine"
+	"CardGameGo/src/screens"
+	"github.com/veandco/go-sdl2/sdl"
+	"time"
+)
+
+// The table of a game watched from another device. The scoreboard is pushed on top of it as an overlay
+type SpectatorScene struct {
+	e          *engine.Engine
+	session    *Session
+	homeButton *imagebutton.ImageButton
+}
+
+// Provided constructor
+func NewSpectatorScene(e *engine.Engine, session *Session) *SpectatorScene {
+	s := &SpectatorScene{
+		e:          e,
+		session:    session,
+		homeButton: newHomeButton(e),
+	}
+
+	// Leaves the game being watched
+	s.homeButton.CallBack = func(i ...interface{}) error {
+		e.CurrentScreen = screens.MainScreen
+		return session.GameUi.LeaveGame()
+	}
+	return s
+}
+
+func (s *SpectatorScene) OnEnter() error {
+	eventManager := s.e.Event[screens.SpectatorScreen]
+	if s.session.SpectatorUi != nil {
+		s.session.SpectatorUi.Register(eventManager)
+	}
+	eventManager.RegisterEvent(s.homeButton)
+	return nil
+}
+
+// The chat loses the keyboard
+func (s *SpectatorScene) OnExit() error {
+	textinput.Blur()
+	return nil
+}
+
+// Moves the game forward, back to the main screen once the device stopped watching
+func (s *SpectatorScene) Update(dt time.Duration) error {
+	if s.session.SpectatorUi == nil {
+		s.e.CurrentScreen = screens.MainScreen
+		return nil
+	}
+
+	err := s.session.GameUi.Update()
+	if err != nil {
+		return err
+	}
+	s.session.wake(s.e)
+	if s.session.SpectatorUi.Done() {
+		s.e.CurrentScreen = screens.MainScreen
+		return nil
+	}
+	if s.session.GameUi.ShowScoreboard && !s.e.Shows(screens.ScoreboardScreen) {
+		return s.e.PushScene(screens.ScoreboardScreen)
+	}
+	return nil
+}
+
+func (s *SpectatorScene) Draw() error {
+	drawBackground(s.e)
+	err := drawHomeButton(s.e, s.homeButton)
+	if err != nil {
+		return err
+	}
+
+	if s.session.SpectatorUi == nil {
+		return nil
+	}
+	w, h := s.e.Window.GetSize()
+	return s.session.SpectatorUi.Draw(w, h, s.e.Renderer)
+}
+
+// The back button leaves the application
+func (s *SpectatorScene) HandleEvent(event sdl.Event) error {
+	handled, err := handleEvent(s.e, screens.SpectatorScreen, event)
+	if !handled && isBack(event) {
+		s.e.Quit()
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+// Keeps the score of a match made of several hands. The scoring rules themselves belong to the game variants
+// (see src/variants), this package only records what every team scored in every hand and keeps the running
+// totals of the match.
+//
+// Like the rules engine, this package must never import SDL.
+package scoring
+
+import (
+	"CardGameGo/src/utils/directions"
+	"strings"
+)
+
+// What a single team scored in a single hand
+type TeamScore struct {
+	// The tricks won by the team
+	Tricks int
+
+	// The number of tricks the team bid, or zero for variants without bidding
+	Bid int
+
+	// The points scored in the hand, penalties included. May be negative
+	Points int
+
+	// The tricks won above the bid ("bags" or "sandbags"). Only used by variants that penalise overtricks
+	Bags int
+}
+
+// The score of a single hand
+type HandScore struct {
+	// The seat that dealt the hand
+	Dealer int
+
+	// The score of every team, in the order of Match.Teams
+	Teams []TeamScore
+}
+
+type Match struct {
+	// The seats scoring together. The index of a team is used to index every other slice of the match
+	Teams [][]int
+
+	// The score of every hand played so far, in order
+	Hands []HandScore
+
+	// The running total of points of every team
+	Totals []int
+
+	// The running total of bags of every team
+	Bags []int
+}
+
+// Provided constructor
+func NewMatch(teams [][]int) *Match {
+	return &Match{
+		Teams:  teams,
+		Hands:  make([]HandScore, 0),
+		Totals: make([]int, len(teams)),
+		Bags:   make([]int, len(teams)),
+	}
+}
+
+// Adds the score of a hand to the match
+func (m *Match) Record(hand HandScore) {
+	m.Hands = append(m.Hands, hand)
+	for i, team := range hand.Teams {
+		m.Totals[i] += team.Points
+		m.Bags[i] += team.Bags
+	}
+}
+
+// Returns the index of the team the seat belongs to, or -1 if the seat is not part of any team
+func (m *Match) TeamOf(seat int) int {
+	for i, team := range m.Teams {
+		for _, s := range team {
+			if s == seat {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Returns the name shown for a team, for example "North/South"
+func TeamName(team []int) string {
+	names := make([]string, len(team))
+	for i, seat := range team {
+		names[i] = directions.String(seat)
+	}
+	return strings.Join(names, "/")
+}
@@ -1,15 +1,31 @@
 // Use this package as an API to the different screens that will be used throughout the application. This
 // package also provides an array with all the screens so that iteration may be done over the screens.
+//
+// Every screen is drawn by the engine.Scene registered for it. The overlays, such as the pause menu, are
+// screens too: they are pushed on top of the current screen rather than replacing it, refer to
+// engine.Engine.PushScene
 package screens
 
 const (
 	MainScreen = iota
 	GameScreen
 	SettingsScreen
+	BiddingScreen
+	LobbyScreen
+	SpectatorScreen
+	ReplayScreen
+	PauseScreen
+	ScoreboardScreen
 )
 
 var Screens = [...]int{
 	MainScreen,
 	GameScreen,
 	SettingsScreen,
+	BiddingScreen,
+	LobbyScreen,
+	SpectatorScreen,
+	ReplayScreen,
+	PauseScreen,
+	ScoreboardScreen,
 }
@@ -13,3 +13,16 @@ var (
 	SILVER = &sdl.Color{R: 191, G: 191, B: 191, A: 1}
 	BRIGHT_GREEN = &sdl.Color{G: 230, B: 64, A: 1}
 )
+
+// Returns the color mixed with white by the given amount, between 0 and 1, for example to draw a button that
+// is hovered. Negative amounts mix it with black instead, for example to draw a button that is pressed
+func Tint(color *sdl.Color, amount float64) *sdl.Color {
+	target := 255.0
+	if amount < 0 {
+		target, amount = 0, -amount
+	}
+	mix := func(c uint8) uint8 {
+		return uint8(float64(c) + (target-float64(c))*amount)
+	}
+	return &sdl.Color{R: mix(color.R), G: mix(color.G), B: mix(color.B), A: color.A}
+}
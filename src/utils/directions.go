@@ -1,25 +1,17 @@
 package utils
 
+import "CardGameGo/src/utils/directions"
+
+// Re-exported from the directions package. Refer to src/utils/directions/directions.go for more info
 const (
-	North = iota
-	East
-	South
-	West
+	North = directions.North
+	East  = directions.East
+	South = directions.South
+	West  = directions.West
 )
 
-var DirectionOrder = [4]int{North, East, South, West}
+var DirectionOrder = directions.Order
 
 func DirectionToString(direction int) string {
-	switch direction {
-	case North:
-		return "North"
-	case East:
-		return "East"
-	case South:
-		return "South"
-	case West:
-		return "West"
-	default:
-		return ""
-	}
+	return directions.String(direction)
 }
@@ -0,0 +1,58 @@
+// The seat directions around the table. These used to live in the utils package but since utils depends on
+// SDL for its rendering helpers, the directions were moved here so that game logic (which must never import
+// SDL) can share them. The utils package still re-exports everything in this file for the rendering code.
+package directions
+
+const (
+	North = iota
+	East
+	South
+	West
+)
+
+// The order in which seats take their turns
+var Order = [4]int{North, East, South, West}
+
+func String(direction int) string {
+	switch direction {
+	case North:
+		return "North"
+	case East:
+		return "East"
+	case South:
+		return "South"
+	case West:
+		return "West"
+	default:
+		return ""
+	}
+}
+
+// Returns the seat that plays after the given seat
+func Next(direction int) int {
+	return Order[(indexOf(direction)+1)%len(Order)]
+}
+
+// Returns the seat sitting across the table from the given seat
+func Partner(direction int) int {
+	return Order[(indexOf(direction)+2)%len(Order)]
+}
+
+// Returns the seats in turn order starting with the given seat
+func From(direction int) []int {
+	start := indexOf(direction)
+	seats := make([]int, len(Order))
+	for i := range seats {
+		seats[i] = Order[(start+i)%len(Order)]
+	}
+	return seats
+}
+
+func indexOf(direction int) int {
+	for i, d := range Order {
+		if d == direction {
+			return i
+		}
+	}
+	return 0
+}
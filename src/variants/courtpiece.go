@@ -0,0 +1,98 @@
+package variants
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+)
+
+// The number of hands a partnership must win to win the match
+const CourtPieceTarget = 7
+
+// Court Piece (also known as Rang) is played by two partnerships with the whole deck. The player after the
+// dealer calls the trump and leads the first trick. The partnership that takes at least seven tricks wins the
+// hand, and taking all thirteen tricks (a "court") counts as two hands.
+//
+// At a real table the trump is called after looking at the first five cards only. Since the hands are dealt
+// all at once here, the caller simply names its longest suit.
+type CourtPiece struct{}
+
+func (CourtPiece) Name() string {
+	return "Court Piece"
+}
+
+func (CourtPiece) NewDeck() *cards.Deck {
+	return cards.NewDeck()
+}
+
+func (CourtPiece) HandSize() int {
+	return 13
+}
+
+func (CourtPiece) HasBidding() bool {
+	return false
+}
+
+func (CourtPiece) BidRule() rules.BidRule {
+	return nil
+}
+
+func (CourtPiece) ChooseTrump(hands map[int][]cards.Card, dealer int) cards.Suit {
+	return LongestSuit(hands[directions.Next(dealer)])
+}
+
+func (CourtPiece) FirstLeader(hands map[int][]cards.Card, dealer int) int {
+	return directions.Next(dealer)
+}
+
+func (CourtPiece) Restriction() rules.Restriction {
+	return nil
+}
+
+func (CourtPiece) Teams() [][]int {
+	return partnerships
+}
+
+func (c CourtPiece) ScoreHand(result HandResult, match *scoring.Match) scoring.HandScore {
+	hand := newHandScore(c, result)
+	for i, team := range hand.Teams {
+		switch {
+		case team.Tricks == len(result.Tricks) && team.Tricks > 0:
+			hand.Teams[i].Points = 2
+		case team.Tricks >= 7:
+			hand.Teams[i].Points = 1
+		}
+	}
+	return hand
+}
+
+func (CourtPiece) Target() int {
+	return CourtPieceTarget
+}
+
+func (CourtPiece) MatchOver(totals []int) (int, bool) {
+	for i, total := range totals {
+		if total >= CourtPieceTarget {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Returns the suit the hand holds the most cards of. Ties are broken in favour of the suit holding the
+// highest card.
+func LongestSuit(hand []cards.Card) cards.Suit {
+	best := cards.NoSuit
+	bestCount := 0
+	for _, suit := range cards.Suits {
+		suited := cards.OfSuit(hand, suit)
+		if len(suited) > bestCount ||
+			(len(suited) == bestCount && len(suited) > 0 &&
+				cards.CompareRank(cards.Highest(suited), cards.Highest(cards.OfSuit(hand, best))) > 0) {
+			best = suit
+			bestCount = len(suited)
+		}
+	}
+	return best
+}
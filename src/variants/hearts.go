@@ -0,0 +1,128 @@
+package variants
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+)
+
+// The match ends as soon as a player reaches this many points
+const HeartsLimit = 100
+
+var twoOfClubs = cards.New(cards.Clubs, cards.Two)
+var queenOfSpades = cards.New(cards.Spades, cards.Queen)
+
+// Hearts is played without trumps and without partners. Every heart taken costs a point and the queen of
+// spades costs thirteen, unless a single player takes every point card ("shooting the moon") in which case
+// everybody else is charged 26 points instead. The two of clubs opens the hand, no point card may be played
+// to the first trick and hearts cannot be led until a heart has been played. The lowest score wins.
+type Hearts struct{}
+
+func (Hearts) Name() string {
+	return "Hearts"
+}
+
+func (Hearts) NewDeck() *cards.Deck {
+	return cards.NewDeck()
+}
+
+func (Hearts) HandSize() int {
+	return 13
+}
+
+func (Hearts) HasBidding() bool {
+	return false
+}
+
+func (Hearts) BidRule() rules.BidRule {
+	return nil
+}
+
+func (Hearts) ChooseTrump(hands map[int][]cards.Card, dealer int) cards.Suit {
+	return cards.NoSuit
+}
+
+func (Hearts) FirstLeader(hands map[int][]cards.Card, dealer int) int {
+	for seat, hand := range hands {
+		if cards.Contains(hand, twoOfClubs) {
+			return seat
+		}
+	}
+	return directions.Next(dealer)
+}
+
+func (Hearts) Restriction() rules.Restriction {
+	return func(t *rules.Table, seat int, legal []cards.Card) []cards.Card {
+		firstTrick := len(t.Tricks) == 0
+		leading := len(t.Trick.Plays) == 0
+
+		switch {
+		case firstTrick && leading:
+			if cards.Contains(legal, twoOfClubs) {
+				return []cards.Card{twoOfClubs}
+			}
+			return legal
+		case firstTrick:
+			return cards.Remove(WithoutSuit(legal, cards.Hearts), queenOfSpades)
+		case leading && !t.SuitPlayed(cards.Hearts):
+			return WithoutSuit(legal, cards.Hearts)
+		default:
+			return legal
+		}
+	}
+}
+
+func (Hearts) Teams() [][]int {
+	return individuals
+}
+
+// Returns the penalty points of a single card
+func HeartsPoints(card cards.Card) int {
+	switch {
+	case card.Suit == cards.Hearts:
+		return 1
+	case card == queenOfSpades:
+		return 13
+	default:
+		return 0
+	}
+}
+
+func (h Hearts) ScoreHand(result HandResult, match *scoring.Match) scoring.HandScore {
+	hand := newHandScore(h, result)
+	for _, trick := range result.Tricks {
+		winner := TeamOf(h, trick.Winner(cards.NoSuit).Seat)
+		for _, play := range trick.Plays {
+			hand.Teams[winner].Points += HeartsPoints(play.Card)
+		}
+	}
+
+	for i, team := range hand.Teams {
+		if team.Points == 26 {
+			for j := range hand.Teams {
+				hand.Teams[j].Points = 26
+			}
+			hand.Teams[i].Points = 0
+			break
+		}
+	}
+	return hand
+}
+
+func (Hearts) Target() int {
+	return HeartsLimit
+}
+
+func (Hearts) MatchOver(totals []int) (int, bool) {
+	best, over := 0, false
+	for i, total := range totals {
+		if total < totals[best] {
+			best = i
+		}
+		if total >= HeartsLimit {
+			over = true
+		}
+	}
+	return best, over
+}
@@ -0,0 +1,152 @@
+package variants
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+)
+
+// The score a partnership must reach to win a game of Spades. A partnership that falls to the losing score
+// loses the match straight away.
+const (
+	SpadesTarget     = 500
+	SpadesLosingMark = -200
+)
+
+// A bid of zero tricks is called a nil bid. A successful nil bid scores NilBonus points for the partnership,
+// a failed one costs as much.
+const (
+	NilBid   = 0
+	NilBonus = 100
+)
+
+// Every BagLimit bags a partnership collects over the match cost it BagPenalty points
+const (
+	BagLimit   = 10
+	BagPenalty = 100
+)
+
+// Spades is played by two partnerships with the whole deck. Spades are always trumps and cannot be led until
+// a spade has been played on another suit. Every partnership bids the number of tricks it expects to win and
+// scores ten points per trick bid if it makes its contract, plus a point per extra trick. Extra tricks are
+// also counted as bags, which are penalised once enough of them are collected. A player may bid nil, betting
+// on not winning a single trick.
+//
+// Every seat bids once, anything from nil up to the tricks its partner left over, so that a partnership never
+// bids more than the thirteen tricks of the hand.
+type Spades struct{}
+
+func (Spades) Name() string {
+	return "Spades"
+}
+
+func (Spades) NewDeck() *cards.Deck {
+	return cards.NewDeck()
+}
+
+func (Spades) HandSize() int {
+	return 13
+}
+
+func (Spades) HasBidding() bool {
+	return true
+}
+
+func (s Spades) BidRule() rules.BidRule {
+	return func(a *rules.Auction, seat int) []int {
+		max := s.HandSize()
+		if partnerBid, ok := a.BidOf(directions.Partner(seat)); ok {
+			max -= partnerBid
+		}
+
+		bids := make([]int, 0, max+1)
+		for bid := NilBid; bid <= max; bid++ {
+			bids = append(bids, bid)
+		}
+		return bids
+	}
+}
+
+func (Spades) ChooseTrump(hands map[int][]cards.Card, dealer int) cards.Suit {
+	return cards.Spades
+}
+
+func (Spades) FirstLeader(hands map[int][]cards.Card, dealer int) int {
+	return directions.Next(dealer)
+}
+
+func (Spades) Restriction() rules.Restriction {
+	return func(t *rules.Table, seat int, legal []cards.Card) []cards.Card {
+		if len(t.Trick.Plays) > 0 || t.SuitPlayed(cards.Spades) {
+			return legal
+		}
+		return WithoutSuit(legal, cards.Spades)
+	}
+}
+
+func (Spades) Teams() [][]int {
+	return partnerships
+}
+
+func (s Spades) ScoreHand(result HandResult, match *scoring.Match) scoring.HandScore {
+	hand := newHandScore(s, result)
+	for i, team := range s.Teams() {
+		score := &hand.Teams[i]
+
+		// Tricks taken by a nil bidder do not count towards the partner's contract, they are bags
+		contractTricks := 0
+		for _, seat := range team {
+			tricks := result.TricksWon[seat]
+			bid, ok := result.Bids[seat]
+			switch {
+			case ok && bid == NilBid && tricks == 0:
+				score.Points += NilBonus
+			case ok && bid == NilBid:
+				score.Points -= NilBonus
+				score.Bags += tricks
+			default:
+				score.Bid += bid
+				contractTricks += tricks
+			}
+		}
+
+		if contractTricks >= score.Bid {
+			score.Points += 10*score.Bid + (contractTricks - score.Bid)
+			score.Bags += contractTricks - score.Bid
+		} else {
+			score.Points -= 10 * score.Bid
+		}
+
+		previousBags := 0
+		if match != nil {
+			previousBags = match.Bags[i]
+		}
+		penalties := (previousBags+score.Bags)/BagLimit - previousBags/BagLimit
+		score.Points -= penalties * BagPenalty
+	}
+	return hand
+}
+
+func (Spades) Target() int {
+	return SpadesTarget
+}
+
+func (Spades) MatchOver(totals []int) (int, bool) {
+	best, over := 0, false
+	for i, total := range totals {
+		if total > totals[best] {
+			best = i
+		}
+		if total >= SpadesTarget {
+			over = true
+		}
+	}
+
+	for i, total := range totals {
+		if total <= SpadesLosingMark {
+			return 1 - i, true
+		}
+	}
+	return best, over
+}
@@ -0,0 +1,165 @@
+// Provides the family of trick taking games that can be played in the application. Every game is described
+// by an implementation of the Variant interface which tells the rest of the application which cards to deal,
+// how the trump is chosen, who leads, which extra play restrictions apply, how a hand is scored and when the
+// match is over. The actual trick taking is always done by the rules engine in src/rules.
+//
+// Like the rules engine, this package must never import SDL.
+package variants
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+	"strconv"
+)
+
+// The outcome of a single hand, as passed to Variant.ScoreHand
+type HandResult struct {
+	// The seat that dealt the hand
+	Dealer int
+
+	// The number of tricks won by every seat, keyed by direction
+	TricksWon map[int]int
+
+	// Every trick of the hand in the order they were played
+	Tricks []rules.Trick
+
+	// The bid of every seat, keyed by direction. Nil for variants without a bidding phase
+	Bids map[int]int
+}
+
+type Variant interface {
+	// The name shown to the players
+	Name() string
+
+	// Returns a new, unshuffled deck with the cards used by the variant
+	NewDeck() *cards.Deck
+
+	// The number of cards dealt to every seat
+	HandSize() int
+
+	// Reports whether the hand starts with a bidding phase
+	HasBidding() bool
+
+	// Returns the rule deciding which bids are legal during the bidding phase, or nil for variants without
+	// a bidding phase
+	BidRule() rules.BidRule
+
+	// Returns the trump suit of a hand given the dealt hands, or cards.NoSuit for a hand without trumps
+	ChooseTrump(hands map[int][]cards.Card, dealer int) cards.Suit
+
+	// Returns the seat leading the first trick of a hand
+	FirstLeader(hands map[int][]cards.Card, dealer int) int
+
+	// Returns the extra play restrictions of the variant, or nil if the basic follow suit rule is enough
+	Restriction() rules.Restriction
+
+	// The seats scoring together. Every team is scored as a whole and the index of a team in this slice is
+	// used to index the scores returned by ScoreHand and passed to MatchOver
+	Teams() [][]int
+
+	// Scores a hand. The match the hand belongs to is passed for variants whose scoring depends on the
+	// previous hands, it may be nil when scoring a hand on its own.
+	ScoreHand(result HandResult, match *scoring.Match) scoring.HandScore
+
+	// The score the match is played to, as shown to the players
+	Target() int
+
+	// Reports whether the match is over given the running total of every team and if so, which team won
+	MatchOver(totals []int) (winner int, over bool)
+}
+
+// Every variant that can be chosen from the main screen. The first variant is the default.
+var All = []Variant{
+	Spades{},
+	Hearts{},
+	CourtPiece{},
+}
+
+// Returns the variant with the given name
+func ByName(name string) (Variant, bool) {
+	for _, variant := range All {
+		if variant.Name() == name {
+			return variant, true
+		}
+	}
+	return nil, false
+}
+
+// Creates the rules engine table for a freshly dealt hand, using the variant's trump, first leader and
+// restriction rules
+func NewTable(v Variant, hands map[int][]cards.Card, dealer int) *rules.Table {
+	table := rules.New(hands, v.FirstLeader(hands, dealer), v.ChooseTrump(hands, dealer))
+	table.Restrict = v.Restriction()
+	return table
+}
+
+// Creates the bidding round of a freshly dealt hand, or returns nil if the variant has no bidding phase. The
+// seat after the dealer bids first.
+func NewAuction(v Variant, dealer int) *rules.Auction {
+	if !v.HasBidding() {
+		return nil
+	}
+	return rules.NewAuction(directions.Next(dealer), v.BidRule())
+}
+
+// Returns the text shown to the players for a bid
+func BidString(bid int) string {
+	if bid == NilBid {
+		return "Nil"
+	}
+	return strconv.Itoa(bid)
+}
+
+// Returns the index of the team the seat belongs to, or -1 if the seat is not part of any team
+func TeamOf(v Variant, seat int) int {
+	for i, team := range v.Teams() {
+		for _, s := range team {
+			if s == seat {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Returns a hand score with the tricks won by every team filled in and no points scored yet
+func newHandScore(v Variant, result HandResult) scoring.HandScore {
+	teams := v.Teams()
+	hand := scoring.HandScore{
+		Dealer: result.Dealer,
+		Teams:  make([]scoring.TeamScore, len(teams)),
+	}
+	for i, team := range teams {
+		for _, seat := range team {
+			hand.Teams[i].Tricks += result.TricksWon[seat]
+		}
+	}
+	return hand
+}
+
+// North and South play against East and West
+var partnerships = [][]int{
+	{directions.North, directions.South},
+	{directions.East, directions.West},
+}
+
+// Every seat plays for itself
+var individuals = [][]int{
+	{directions.North},
+	{directions.East},
+	{directions.South},
+	{directions.West},
+}
+
+// Returns the cards that are not of the given suit
+func WithoutSuit(hand []cards.Card, suit cards.Suit) []cards.Card {
+	result := make([]cards.Card, 0, len(hand))
+	for _, card := range hand {
+		if card.Suit != suit {
+			result = append(result, card)
+		}
+	}
+	return result
+}
@@ -0,0 +1,284 @@
+package variants
+
+import (
+	"CardGameGo/src/cards"
+	"CardGameGo/src/rules"
+	"CardGameGo/src/scoring"
+	"CardGameGo/src/utils/directions"
+	"strings"
+	"testing"
+)
+
+// Parses cards written like "h2 sQ c1", refer to cards.Parse
+func parse(t *testing.T, names string) []cards.Card {
+	hand, err := cards.ParseAll(strings.Fields(names)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return hand
+}
+
+// Returns a trick led by the seat, the cards being played in turn from there
+func trick(t *testing.T, leader int, plays string) rules.Trick {
+	trick := rules.Trick{Leader: leader}
+	seat := leader
+	for _, card := range parse(t, plays) {
+		trick.Plays = append(trick.Plays, rules.Play{Seat: seat, Card: card})
+		seat = directions.Next(seat)
+	}
+	return trick
+}
+
+// Deals the hands, in directions.Order, to a table of the variant dealt by West
+func newTestTable(t *testing.T, v Variant, hands [4]string) *rules.Table {
+	dealt := make(map[int][]cards.Card)
+	for i, seat := range directions.Order {
+		dealt[seat] = parse(t, hands[i])
+	}
+	return NewTable(v, dealt, directions.West)
+}
+
+// Returns the tricks won by every seat, in directions.Order
+func tricksWon(north, east, south, west int) map[int]int {
+	return map[int]int{
+		directions.North: north,
+		directions.East:  east,
+		directions.South: south,
+		directions.West:  west,
+	}
+}
+
+func TestSpadesScoring(t *testing.T) {
+	tests := []struct {
+		name  string
+		won   map[int]int
+		bids  map[int]int
+		bags  []int
+		score []scoring.TeamScore
+	}{
+		{
+			name: "contracts made, with a bag",
+			won:  tricksWon(4, 4, 2, 3),
+			bids: tricksWon(3, 4, 2, 3),
+			bags: []int{0, 0},
+			score: []scoring.TeamScore{
+				{Tricks: 6, Bid: 5, Points: 51, Bags: 1},
+				{Tricks: 7, Bid: 7, Points: 70},
+			},
+		},
+		{
+			name: "contract set",
+			won:  tricksWon(3, 4, 3, 3),
+			bids: tricksWon(5, 2, 3, 2),
+			bags: []int{0, 0},
+			score: []scoring.TeamScore{
+				{Tricks: 6, Bid: 8, Points: -80},
+				{Tricks: 7, Bid: 4, Points: 43, Bags: 3},
+			},
+		},
+		{
+			name: "nil made",
+			won:  tricksWon(0, 4, 5, 4),
+			bids: tricksWon(NilBid, 4, 4, 4),
+			bags: []int{0, 0},
+			score: []scoring.TeamScore{
+				{Tricks: 5, Bid: 4, Points: NilBonus + 41, Bags: 1},
+				{Tricks: 8, Bid: 8, Points: 80},
+			},
+		},
+		{
+			name: "nil failed",
+			won:  tricksWon(2, 3, 4, 4),
+			bids: tricksWon(NilBid, 3, 4, 4),
+			bags: []int{0, 0},
+			score: []scoring.TeamScore{
+				{Tricks: 6, Bid: 4, Points: -NilBonus + 40, Bags: 2},
+				{Tricks: 7, Bid: 7, Points: 70},
+			},
+		},
+		{
+			name: "bags penalised",
+			won:  tricksWon(5, 2, 3, 3),
+			bids: tricksWon(3, 2, 3, 3),
+			bags: []int{8, 9},
+			score: []scoring.TeamScore{
+				{Tricks: 8, Bid: 6, Points: 62 - BagPenalty, Bags: 2},
+				{Tricks: 5, Bid: 5, Points: 50},
+			},
+		},
+	}
+	for _, test := range tests {
+		match := scoring.NewMatch(Spades{}.Teams())
+		copy(match.Bags, test.bags)
+		hand := Spades{}.ScoreHand(HandResult{TricksWon: test.won, Bids: test.bids}, match)
+		for i, expected := range test.score {
+			if hand.Teams[i] != expected {
+				t.Errorf("%s: team %d scored %+v, expected %+v", test.name, i, hand.Teams[i], expected)
+			}
+		}
+	}
+}
+
+func TestHeartsScoring(t *testing.T) {
+	tests := []struct {
+		name   string
+		tricks []string
+		points []int
+	}{
+		{
+			name:   "the point cards are charged to the winner of the trick",
+			tricks: []string{"h2 h9 hK h5", "s3 c2 sQ s1"},
+			points: []int{0, 0, 4, 13},
+		},
+		{
+			name:   "no point card taken",
+			tricks: []string{"c2 c5 cK c1", "d3 d4 d5 d6"},
+			points: []int{0, 0, 0, 0},
+		},
+		{
+			name:   "shooting the moon",
+			tricks: []string{"h1 h2 h3 h4", "hK h5 h6 h7", "hQ h8 h9 hX", "hJ sQ c2 c3"},
+			points: []int{0, 26, 26, 26},
+		},
+	}
+	for _, test := range tests {
+		result := HandResult{TricksWon: map[int]int{}}
+		for _, plays := range test.tricks {
+			result.Tricks = append(result.Tricks, trick(t, directions.North, plays))
+		}
+		hand := Hearts{}.ScoreHand(result, nil)
+		for i, points := range test.points {
+			if hand.Teams[i].Points != points {
+				t.Errorf("%s: %s scored %d, expected %d", test.name, directions.String(directions.Order[i]),
+					hand.Teams[i].Points, points)
+			}
+		}
+	}
+}
+
+func TestCourtPieceScoring(t *testing.T) {
+	tests := []struct {
+		name   string
+		won    map[int]int
+		points []int
+	}{
+		{"seven tricks win the hand", tricksWon(4, 3, 3, 3), []int{1, 0}},
+		{"the other partnership wins the hand", tricksWon(2, 5, 1, 5), []int{0, 1}},
+		{"a court counts as two hands", tricksWon(6, 0, 7, 0), []int{2, 0}},
+	}
+	for _, test := range tests {
+		result := HandResult{TricksWon: test.won, Tricks: make([]rules.Trick, 13)}
+		hand := CourtPiece{}.ScoreHand(result, nil)
+		for i, points := range test.points {
+			if hand.Teams[i].Points != points {
+				t.Errorf("%s: team %d scored %d, expected %d", test.name, i, hand.Teams[i].Points, points)
+			}
+		}
+	}
+}
+
+func TestLongestSuit(t *testing.T) {
+	tests := []struct {
+		hand string
+		suit cards.Suit
+	}{
+		{"h2 h3 h4 s1 sK d2", cards.Hearts},
+		{"c2 c3 d4 d5 s6", cards.Diamonds},
+		{"c2 c1 d4 dK", cards.Clubs},
+		{"sX", cards.Spades},
+		{"", cards.NoSuit},
+	}
+	for _, test := range tests {
+		if suit := LongestSuit(parse(t, test.hand)); suit != test.suit {
+			t.Errorf("%q: got %s, expected %s", test.hand, suit, test.suit)
+		}
+	}
+}
+
+func TestCourtPieceTrumpIsCalledAfterTheDealer(t *testing.T) {
+	// West deals, North calls hearts and leads
+	table := newTestTable(t, CourtPiece{}, [4]string{"h2 h3 s1", "s2 s3 h1", "d2 d3 d4", "c2 c3 c4"})
+	if table.Trump != cards.Hearts {
+		t.Fatalf("the trump is %s, expected the longest suit of North", table.Trump)
+	}
+	if table.Current != directions.North {
+		t.Fatalf("%s leads, expected North", directions.String(table.Current))
+	}
+}
+
+func TestPlayRestrictions(t *testing.T) {
+	tests := []struct {
+		name   string
+		v      Variant
+		hands  [4]string
+		played string
+		seat   int
+		legal  string
+	}{
+		{
+			name:  "spades cannot be led until broken",
+			v:     Spades{},
+			hands: [4]string{"s1 h2 d3", "c2 c3 c4", "c5 c6 c7", "c8 c9 cX"},
+			seat:  directions.North,
+			legal: "h2 d3",
+		},
+		{
+			name:  "spades are led when nothing else is held",
+			v:     Spades{},
+			hands: [4]string{"s1 s2", "c2 c3", "c5 c6", "c8 c9"},
+			seat:  directions.North,
+			legal: "s1 s2",
+		},
+		{
+			name:  "the two of clubs opens a hand of Hearts",
+			v:     Hearts{},
+			hands: [4]string{"c5 h2", "c2 d3", "c6 s4", "c7 d5"},
+			seat:  directions.East,
+			legal: "c2",
+		},
+		{
+			name:   "no point card is played to the first trick",
+			v:      Hearts{},
+			hands:  [4]string{"d4 h2 sQ", "c2 d3 d6", "c6 s4 s5", "c7 d5 d7"},
+			played: "c2 c6 c7",
+			seat:   directions.North,
+			legal:  "d4",
+		},
+		{
+			name:   "hearts cannot be led until broken",
+			v:      Hearts{},
+			hands:  [4]string{"c3 h2 d2", "c2 h4 d3", "c6 h5 d4", "c1 h6 d5"},
+			played: "c2 c6 c1 c3",
+			seat:   directions.West,
+			legal:  "d5",
+		},
+	}
+	for _, test := range tests {
+		table := newTestTable(t, test.v, test.hands)
+		for _, card := range parse(t, test.played) {
+			err := table.Play(table.Current, card)
+			if err != nil {
+				t.Fatalf("%s: %s", test.name, err)
+			}
+		}
+		if table.TrickComplete() {
+			_, err := table.Collect()
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		legal := table.LegalMoves(test.seat)
+		expected := parse(t, test.legal)
+		if len(legal) != len(expected) {
+			t.Errorf("%s: got %v, expected %s", test.name, legal, test.legal)
+			continue
+		}
+		for i := range legal {
+			if legal[i] != expected[i] {
+				t.Errorf("%s: got %v, expected %s", test.name, legal, test.legal)
+				break
+			}
+		}
+	}
+}